@@ -0,0 +1,248 @@
+package detectors
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+// PSKModulation - вид фазовой манипуляции для петли Костаса
+type PSKModulation int
+
+const (
+	BPSK PSKModulation = 2 // Двухпозиционная ФМн: {±1}
+	QPSK PSKModulation = 4 // Четырехпозиционная ФМн: {±1 ± j}/√2
+	PSK8 PSKModulation = 8 // Восьмипозиционная ФМн: exp(jπk/4)
+)
+
+// String возвращает название модуляции
+func (m PSKModulation) String() string {
+	switch m {
+	case BPSK:
+		return "BPSK"
+	case QPSK:
+		return "QPSK"
+	case PSK8:
+		return "8PSK"
+	default:
+		return "Unknown"
+	}
+}
+
+// constellationOffset возвращает фазу первой точки созвездия
+func (m PSKModulation) constellationOffset() float64 {
+	if m == QPSK {
+		return math.Pi / 4
+	}
+	return 0
+}
+
+// CostasConfig - параметры петли Костаса
+type CostasConfig struct {
+	SampleRate float64       // Частота следования отсчетов (символов) на входе петли (Гц)
+	Modulation PSKModulation // Вид модуляции
+	Bandwidth  float64       // Собственная частота петли (Гц)
+	Damping    float64       // Коэффициент затухания
+	Order      int           // Порядок петли (2 или 3)
+
+	LockTime        float64 // Постоянная времени детектора захвата (с)
+	LockThreshold   float64 // Порог захвата для метрики <cos(M·e)>
+	UnlockThreshold float64 // Порог срыва
+}
+
+// DefaultCostasConfig возвращает параметры по умолчанию (петля 2-го порядка, затухание 0.707)
+func DefaultCostasConfig(sampleRate float64, modulation PSKModulation, bandwidth float64) CostasConfig {
+	pll := DefaultPLLConfig(sampleRate, bandwidth)
+	return CostasConfig{
+		SampleRate:      sampleRate,
+		Modulation:      modulation,
+		Bandwidth:       bandwidth,
+		Damping:         pll.Damping,
+		Order:           pll.Order,
+		LockTime:        pll.LockTime,
+		LockThreshold:   pll.LockThreshold,
+		UnlockThreshold: pll.UnlockThreshold,
+	}
+}
+
+// CostasLoop реализует петлю Костаса для восстановления несущей сигналов M-PSK
+// Используется фазовый детектор с обратной связью по решению: ошибка равна
+// углу между повернутым отсчетом и ближайшей точкой созвездия, e ∈ (-π/M, π/M].
+// Фильтр петли рассчитывается так же, как в PLLFrequencyDetector (loopFilterGains).
+// Восстановленная фаза имеет неоднозначность 2πk/M, которую необходимо
+// разрешать на более высоком уровне (дифференциальное кодирование, преамбула).
+type CostasLoop struct {
+	sampleRate float64
+	modulation PSKModulation
+	bandwidth  float64
+	damping    float64
+	order      int
+
+	alpha, beta, gamma float64 // Коэффициенты фильтра петли
+
+	phase     float64 // Фаза ГУН (радианы)
+	frequency float64 // Частота ГУН (радиан/отсчет)
+	rate      float64 // Скорость изменения частоты (3-й порядок)
+	lastError float64 // Последняя ошибка фазы
+
+	lock pllLockDetector
+}
+
+// NewCostasLoop создает петлю Костаса
+func NewCostasLoop(config CostasConfig) (*CostasLoop, error) {
+	switch config.Modulation {
+	case BPSK, QPSK, PSK8:
+	default:
+		return nil, fmt.Errorf("unsupported modulation order %d", config.Modulation)
+	}
+	pllConfig := PLLConfig{
+		SampleRate:      config.SampleRate,
+		Bandwidth:       config.Bandwidth,
+		Damping:         config.Damping,
+		Order:           config.Order,
+		LockTime:        config.LockTime,
+		LockThreshold:   config.LockThreshold,
+		UnlockThreshold: config.UnlockThreshold,
+	}
+	if err := pllConfig.Validate(); err != nil {
+		return nil, err
+	}
+
+	cl := &CostasLoop{
+		sampleRate: config.SampleRate,
+		modulation: config.Modulation,
+		bandwidth:  config.Bandwidth,
+		damping:    config.Damping,
+		order:      config.Order,
+		lock: pllLockDetector{
+			lockTime:        config.LockTime,
+			lambda:          1 - math.Exp(-1/(config.LockTime*config.SampleRate)),
+			lockThreshold:   config.LockThreshold,
+			unlockThreshold: config.UnlockThreshold,
+			fold:            float64(config.Modulation),
+		},
+	}
+	cl.alpha, cl.beta, cl.gamma = loopFilterGains(cl.bandwidth, cl.sampleRate, cl.damping, cl.order)
+	return cl, nil
+}
+
+// Tick обрабатывает один отсчет и возвращает отсчет, повернутый на оценку фазы несущей
+func (cl *CostasLoop) Tick(input complex128) complex128 {
+	derotated := input * cmplx.Exp(complex(0, -cl.phase))
+
+	var phaseError float64
+	if cmplx.Abs(derotated) > 1e-10 {
+		_, decision := cl.Decide(derotated)
+		phaseError = cmplx.Phase(derotated * cmplx.Conj(decision))
+	}
+	cl.lastError = phaseError
+
+	// Фильтр петли
+	cl.phase += cl.frequency + cl.alpha*phaseError
+	cl.frequency += cl.rate + cl.beta*phaseError
+	if cl.order == 3 {
+		cl.rate += cl.gamma * phaseError
+	}
+
+	// Частота ограничена однозначной областью детектора ±π/M
+	maxFreq := math.Pi / float64(cl.modulation)
+	if cl.frequency > maxFreq {
+		cl.frequency = maxFreq
+		cl.rate = 0
+	} else if cl.frequency < -maxFreq {
+		cl.frequency = -maxFreq
+		cl.rate = 0
+	}
+	cl.phase = normalizePhase(cl.phase)
+
+	cl.lock.update(phaseError, cl.GetFrequencyOffset())
+	return derotated
+}
+
+// Process обрабатывает блок отсчетов и возвращает повернутые отсчеты
+func (cl *CostasLoop) Process(input []complex128) []complex128 {
+	output := make([]complex128, len(input))
+	for i, x := range input {
+		output[i] = cl.Tick(x)
+	}
+	return output
+}
+
+// ProcessSymbols обрабатывает блок и возвращает повернутые отсчеты и номера решений
+func (cl *CostasLoop) ProcessSymbols(input []complex128) ([]complex128, []int) {
+	output := make([]complex128, len(input))
+	symbols := make([]int, len(input))
+	for i, x := range input {
+		output[i] = cl.Tick(x)
+		symbols[i], _ = cl.Decide(output[i])
+	}
+	return output, symbols
+}
+
+// Decide возвращает номер ближайшей точки созвездия и саму точку (единичной амплитуды)
+// Точка k имеет фазу 2πk/M (для QPSK дополнительно сдвинута на π/4).
+func (cl *CostasLoop) Decide(sample complex128) (int, complex128) {
+	m := float64(cl.modulation)
+	offset := cl.modulation.constellationOffset()
+	step := 2 * math.Pi / m
+
+	k := int(math.Round((cmplx.Phase(sample) - offset) / step))
+	k = ((k % int(m)) + int(m)) % int(m)
+	return k, cmplx.Exp(complex(0, offset+float64(k)*step))
+}
+
+// Reset сбрасывает состояние петли
+func (cl *CostasLoop) Reset() {
+	cl.phase = 0
+	cl.frequency = 0
+	cl.rate = 0
+	cl.lastError = 0
+	cl.lock.reset()
+}
+
+// SetBandwidth устанавливает новую собственную частоту петли
+func (cl *CostasLoop) SetBandwidth(bandwidth float64) error {
+	if bandwidth <= 0 || bandwidth >= cl.sampleRate/2 {
+		return fmt.Errorf("bandwidth must be in (0, %f), got %f", cl.sampleRate/2, bandwidth)
+	}
+	cl.bandwidth = bandwidth
+	cl.alpha, cl.beta, cl.gamma = loopFilterGains(cl.bandwidth, cl.sampleRate, cl.damping, cl.order)
+	return nil
+}
+
+// GetFrequencyOffset возвращает оценку частотной расстройки несущей (Гц)
+func (cl *CostasLoop) GetFrequencyOffset() float64 {
+	return cl.frequency * cl.sampleRate / (2 * math.Pi)
+}
+
+// GetPhase возвращает текущую оценку фазы несущей (радианы, [-π, π])
+func (cl *CostasLoop) GetPhase() float64 {
+	return cl.phase
+}
+
+// GetPhaseError возвращает ошибку фазового детектора на последнем отсчете
+func (cl *CostasLoop) GetPhaseError() float64 {
+	return cl.lastError
+}
+
+// GetPhaseErrorVariance возвращает усредненную дисперсию ошибки фазы (рад²)
+func (cl *CostasLoop) GetPhaseErrorVariance() float64 {
+	return math.Max(0, cl.lock.errSquare-cl.lock.errMean*cl.lock.errMean)
+}
+
+// IsLocked возвращает true, если петля находится в состоянии захвата
+func (cl *CostasLoop) IsLocked() bool {
+	return cl.lock.locked
+}
+
+// TakeLockEvents возвращает накопленные события захвата и срыва и очищает очередь
+func (cl *CostasLoop) TakeLockEvents() []PLLLockEvent {
+	events := cl.lock.events
+	cl.lock.events = nil
+	return events
+}
+
+// GetModulation возвращает вид модуляции
+func (cl *CostasLoop) GetModulation() PSKModulation {
+	return cl.modulation
+}
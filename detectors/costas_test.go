@@ -0,0 +1,117 @@
+package detectors
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+// pskSignal формирует символы M-PSK с частотной и фазовой расстройкой и шумом
+func pskSignal(modulation PSKModulation, n int, fs, offset, phase, sigma float64, seed int64) ([]complex128, []int) {
+	rng := rand.New(rand.NewSource(seed))
+	m := int(modulation)
+	step := 2 * math.Pi / float64(m)
+
+	signal := make([]complex128, n)
+	symbols := make([]int, n)
+	for i := range signal {
+		symbols[i] = rng.Intn(m)
+		theta := modulation.constellationOffset() + float64(symbols[i])*step
+		theta += 2*math.Pi*offset*float64(i)/fs + phase
+		signal[i] = cmplx.Exp(complex(0, theta)) + complex(sigma*rng.NormFloat64(), sigma*rng.NormFloat64())
+	}
+	return signal, symbols
+}
+
+// TestCostasLoopCarrierRecovery проверяет восстановление несущей для BPSK, QPSK и 8PSK
+func TestCostasLoopCarrierRecovery(t *testing.T) {
+	fs := 10000.0
+	offset := 50.0
+
+	for _, modulation := range []PSKModulation{BPSK, QPSK, PSK8} {
+		loop, err := NewCostasLoop(DefaultCostasConfig(fs, modulation, 100))
+		if err != nil {
+			t.Fatalf("%s: NewCostasLoop failed: %v", modulation, err)
+		}
+
+		n := 6000
+		signal, sent := pskSignal(modulation, n, fs, offset, 0.7, 0.05, 11)
+		_, decided := loop.ProcessSymbols(signal)
+
+		if f := loop.GetFrequencyOffset(); math.Abs(f-offset) > 2 {
+			t.Errorf("%s: frequency offset %f Hz, want %f", modulation, f, offset)
+		}
+		if !loop.IsLocked() {
+			t.Errorf("%s: loop should be locked", modulation)
+		}
+
+		// После сходимости решения совпадают с переданными символами с точностью до поворота 2πk/M
+		m := int(modulation)
+		ambiguity := ((decided[n/2]-sent[n/2])%m + m) % m
+		errors := 0
+		for i := n / 2; i < n; i++ {
+			if (sent[i]+ambiguity)%m != decided[i] {
+				errors++
+			}
+		}
+		if errors > 0 {
+			t.Errorf("%s: %d symbol errors after convergence", modulation, errors)
+		}
+	}
+}
+
+// TestCostasLoopDecide проверяет решения по созвездию
+func TestCostasLoopDecide(t *testing.T) {
+	loop, _ := NewCostasLoop(DefaultCostasConfig(1000, QPSK, 10))
+	k, point := loop.Decide(complex(-1, 1.2))
+	if k != 1 || cmplx.Abs(point-cmplx.Exp(complex(0, 3*math.Pi/4))) > 1e-12 {
+		t.Errorf("QPSK decision %d %v, want 1", k, point)
+	}
+
+	loop, _ = NewCostasLoop(DefaultCostasConfig(1000, BPSK, 10))
+	if k, _ := loop.Decide(complex(-0.3, 0.9)); k != 1 {
+		t.Errorf("BPSK decision %d, want 1", k)
+	}
+
+	// Отсутствие сигнала не порождает ошибку фазы
+	loop.Tick(0)
+	if loop.GetPhaseError() != 0 {
+		t.Error("zero input should give zero phase error")
+	}
+
+	config := DefaultCostasConfig(1000, 3, 10)
+	if _, err := NewCostasLoop(config); err == nil {
+		t.Error("expected error for unsupported modulation")
+	}
+	config = DefaultCostasConfig(1000, QPSK, 0)
+	if _, err := NewCostasLoop(config); err == nil {
+		t.Error("expected error for zero bandwidth")
+	}
+}
+
+// TestCostasLoopNoiseDoesNotLock проверяет, что на чистом шуме захват не фиксируется
+// (свернутая ошибка M-PSK не должна давать высокую метрику захвата)
+func TestCostasLoopNoiseDoesNotLock(t *testing.T) {
+	fs := 10000.0
+	for _, modulation := range []PSKModulation{BPSK, QPSK, PSK8} {
+		loop, err := NewCostasLoop(DefaultCostasConfig(fs, modulation, 100))
+		if err != nil {
+			t.Fatalf("%s: NewCostasLoop failed: %v", modulation, err)
+		}
+
+		rng := rand.New(rand.NewSource(int64(modulation)))
+		sigma := math.Sqrt(0.5) // Комплексный шум единичной мощности
+		for i := 0; i < 20000; i++ {
+			loop.Tick(complex(sigma*rng.NormFloat64(), sigma*rng.NormFloat64()))
+			if loop.IsLocked() {
+				t.Fatalf("%s: loop reports lock on noise at sample %d", modulation, i)
+			}
+		}
+		for _, event := range loop.TakeLockEvents() {
+			if event.Type == PLLLocked {
+				t.Errorf("%s: unexpected lock event at sample %d", modulation, event.Sample)
+			}
+		}
+	}
+}
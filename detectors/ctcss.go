@@ -0,0 +1,193 @@
+package detectors
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Alexxtn105/dsp/filters"
+	"github.com/Alexxtn105/dsp/generators"
+)
+
+// CTCSSTones - стандартный ряд частот CTCSS (EIA/TIA-603), Гц
+// Копия таблицы пакета generators: изменение одной из них не затрагивает другую.
+var CTCSSTones = append([]float64(nil), generators.CTCSSTones...)
+
+// CTCSSEvent описывает появление или пропадание субтона
+type CTCSSEvent struct {
+	Type      ToneEventType // KeyDown - субтон появился, KeyUp - пропал
+	Frequency float64       // Номинальная частота субтона (Гц)
+	Sample    int           // Номер отсчета начала события
+	Time      float64       // Время начала события в секундах
+}
+
+// CTCSSConfig конфигурация детектора CTCSS
+type CTCSSConfig struct {
+	SampleRate       float64   // Частота дискретизации (Гц)
+	BlockSize        int       // Длина блока анализа в отсчетах
+	Tones            []float64 // Отслеживаемые частоты (nil - стандартный ряд CTCSSTones)
+	MinLevel         float64   // Минимальная амплитуда субтона
+	MinRelativeDB    float64   // Минимальное превышение над остальными субтонами ряда, дБ
+	Tolerance        float64   // Допустимое отклонение частоты, Гц
+	MinToneDuration  float64   // Минимальная гарантированно принимаемая длительность субтона, с
+	MinPauseDuration float64   // Минимальная длительность пропадания субтона, с
+}
+
+// DefaultCTCSSConfig возвращает типовую конфигурацию детектора CTCSS
+// Блок 400 мс дает разрешение 2.5 Гц, достаточное для соседних тонов ряда.
+func DefaultCTCSSConfig(sampleRate float64) CTCSSConfig {
+	return CTCSSConfig{
+		SampleRate:       sampleRate,
+		BlockSize:        int(math.Round(0.4 * sampleRate)),
+		MinLevel:         0.005,
+		MinRelativeDB:    6,
+		Tolerance:        0.6,
+		MinToneDuration:  0.8,
+		MinPauseDuration: 0.8,
+	}
+}
+
+// validate проверяет корректность конфигурации
+func (c CTCSSConfig) validate() error {
+	if c.SampleRate <= 0 {
+		return fmt.Errorf("sample rate must be positive, got %f", c.SampleRate)
+	}
+	if c.BlockSize < 16 {
+		return fmt.Errorf("block size must be at least 16 samples, got %d", c.BlockSize)
+	}
+	if c.MinLevel < 0 || c.Tolerance < 0 {
+		return fmt.Errorf("level and tolerance must be non-negative")
+	}
+	if c.MinToneDuration <= 0 || c.MinPauseDuration <= 0 {
+		return fmt.Errorf("tone and pause durations must be positive")
+	}
+	return nil
+}
+
+// CTCSSDetector обнаруживает субтоны CTCSS на фоне речевого сигнала
+// Каждый тон ряда анализируется тремя бинами Герцеля (номинал и ±fs/N);
+// тон определяется по наибольшему номинальному бину, частота уточняется интерполяцией.
+type CTCSSDetector struct {
+	config    CTCSSConfig
+	tones     []float64
+	bank      *filters.GoertzelBank
+	step      float64        // Шаг между бинами одной частоты (Гц)
+	sequencer *toneSequencer // Логика длительностей
+	lastFreq  float64        // Уточненная частота последнего принятого блока
+}
+
+// NewCTCSSDetector создает детектор CTCSS с заданной конфигурацией
+func NewCTCSSDetector(config CTCSSConfig) (*CTCSSDetector, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	tones := config.Tones
+	if tones == nil {
+		tones = CTCSSTones
+	}
+	if len(tones) < 2 {
+		return nil, fmt.Errorf("at least two tones are required, got %d", len(tones))
+	}
+	tones = append([]float64{}, tones...)
+
+	step := config.SampleRate / float64(config.BlockSize)
+	bins := make([]float64, 0, 3*len(tones))
+	for _, f := range tones {
+		bins = append(bins, f-step, f, f+step)
+	}
+
+	bank, err := filters.NewGoertzelBank(bins, config.SampleRate, config.BlockSize)
+	if err != nil {
+		return nil, err
+	}
+	bank.SetWorkers(4)
+
+	return &CTCSSDetector{
+		config:    config,
+		tones:     tones,
+		bank:      bank,
+		step:      step,
+		sequencer: newToneSequencer(config.SampleRate, config.BlockSize, config.MinToneDuration, config.MinPauseDuration),
+	}, nil
+}
+
+// Process обрабатывает очередную порцию отсчетов и возвращает события появления и пропадания субтона
+func (d *CTCSSDetector) Process(samples []float64) []CTCSSEvent {
+	return d.toEvents(d.sequencer.process(samples, d.analyzeBlock))
+}
+
+// analyzeBlock анализирует блок и возвращает индекс тона + 1 или 0
+func (d *CTCSSDetector) analyzeBlock(block []float64) int {
+	result, err := d.bank.ProcessBlock(block)
+	if err != nil {
+		return 0
+	}
+	mags := result.Magnitudes
+
+	best, second := -1, 0.0
+	for i := range d.tones {
+		m := mags[3*i+1]
+		if best < 0 || m > mags[3*best+1] {
+			if best >= 0 {
+				second = mags[3*best+1]
+			}
+			best = i
+		} else if m > second {
+			second = m
+		}
+	}
+
+	center := mags[3*best+1]
+	if toDB(center/second) < d.config.MinRelativeDB {
+		return 0
+	}
+
+	freq, level := interpolateTone(mags[3*best], center, mags[3*best+2], d.tones[best], d.step)
+	if level < d.config.MinLevel || math.Abs(freq-d.tones[best]) > d.config.Tolerance {
+		return 0
+	}
+
+	d.lastFreq = freq
+	return best + 1
+}
+
+// toEvents преобразует события логики длительностей в события CTCSS
+func (d *CTCSSDetector) toEvents(events []sequencerEvent) []CTCSSEvent {
+	if len(events) == 0 {
+		return nil
+	}
+	result := make([]CTCSSEvent, len(events))
+	for i, e := range events {
+		result[i] = CTCSSEvent{
+			Type:      e.eventType,
+			Frequency: d.tones[e.code-1],
+			Sample:    e.sample,
+			Time:      float64(e.sample) / d.config.SampleRate,
+		}
+	}
+	return result
+}
+
+// Flush завершает поток: если субтон активен, формирует событие его пропадания
+func (d *CTCSSDetector) Flush() []CTCSSEvent {
+	return d.toEvents(d.sequencer.flush())
+}
+
+// Reset сбрасывает состояние детектора
+func (d *CTCSSDetector) Reset() {
+	d.sequencer.reset()
+	d.lastFreq = 0
+}
+
+// ActiveTone возвращает номинальную частоту активного субтона и признак его наличия
+func (d *CTCSSDetector) ActiveTone() (float64, bool) {
+	code, down := d.sequencer.active()
+	if !down {
+		return 0, false
+	}
+	return d.tones[code-1], true
+}
+
+// GetMeasuredFrequency возвращает уточненную частоту субтона в последнем принятом блоке
+func (d *CTCSSDetector) GetMeasuredFrequency() float64 {
+	return d.lastFreq
+}
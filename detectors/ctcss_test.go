@@ -0,0 +1,106 @@
+package detectors
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Alexxtn105/dsp/generators"
+)
+
+func TestCTCSSDetectorStandardTones(t *testing.T) {
+	for _, f := range []float64{67.0, 69.3, 100.0, 151.4, 156.7, 254.1} {
+		gen := generators.NewCTCSSGenerator(f)
+		gen.Duration = 2
+		gen.VoiceAmplitude = 0.3
+		signal, err := gen.Generate()
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+
+		detector, err := NewCTCSSDetector(DefaultCTCSSConfig(gen.SampleRate))
+		if err != nil {
+			t.Fatalf("NewCTCSSDetector failed: %v", err)
+		}
+		events := detector.Process(signal)
+		if len(events) != 1 || events[0].Type != KeyDown || events[0].Frequency != f {
+			t.Errorf("%.1f Hz: unexpected events %+v", f, events)
+			continue
+		}
+		if tone, ok := detector.ActiveTone(); !ok || tone != f {
+			t.Errorf("%.1f Hz: active tone %.1f, %v", f, tone, ok)
+		}
+		if m := detector.GetMeasuredFrequency(); math.Abs(m-f) > 0.2 {
+			t.Errorf("%.1f Hz: measured frequency %.2f", f, m)
+		}
+	}
+}
+
+// TestCTCSSTonesIndependent проверяет, что таблица детектора не разделяет память с генератором
+func TestCTCSSTonesIndependent(t *testing.T) {
+	if len(CTCSSTones) != len(generators.CTCSSTones) {
+		t.Fatalf("got %d tones, generators has %d", len(CTCSSTones), len(generators.CTCSSTones))
+	}
+	original := CTCSSTones[0]
+	CTCSSTones[0] = 1
+	defer func() { CTCSSTones[0] = original }()
+	if generators.CTCSSTones[0] != original {
+		t.Error("modifying detectors.CTCSSTones changed generators.CTCSSTones")
+	}
+}
+
+// TestCTCSSDetectorTolerance проверяет допуск на отклонение частоты
+func TestCTCSSDetectorTolerance(t *testing.T) {
+	detect := func(freq float64) []CTCSSEvent {
+		gen := generators.NewCTCSSGenerator(freq)
+		gen.Duration = 2
+		signal, _ := gen.Generate()
+		detector, _ := NewCTCSSDetector(DefaultCTCSSConfig(gen.SampleRate))
+		return detector.Process(signal)
+	}
+
+	if events := detect(100.4); len(events) != 1 || events[0].Frequency != 100.0 {
+		t.Errorf("100.4 Hz should be accepted as 100.0 Hz, got %+v", events)
+	}
+	if events := detect(102.0); len(events) != 0 {
+		t.Errorf("102.0 Hz is between standard tones and should be rejected, got %+v", events)
+	}
+}
+
+// TestCTCSSDetectorEvents проверяет появление и пропадание субтона
+func TestCTCSSDetectorEvents(t *testing.T) {
+	gen := generators.NewCTCSSGenerator(123.0)
+	gen.LeadingSilence = 1
+	gen.Duration = 3
+	gen.TrailingTime = 2
+	gen.VoiceAmplitude = 0.3
+	gen.NoiseAmplitude = 0.02
+	gen.Seed = 11
+	signal, _ := gen.Generate()
+
+	detector, _ := NewCTCSSDetector(DefaultCTCSSConfig(gen.SampleRate))
+	events := detector.Process(signal)
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %+v", events)
+	}
+	if events[0].Type != KeyDown || math.Abs(events[0].Time-1) > 0.4 {
+		t.Errorf("tone on event %+v, want KeyDown near 1 s", events[0])
+	}
+	if events[1].Type != KeyUp || math.Abs(events[1].Time-4) > 0.4 {
+		t.Errorf("tone off event %+v, want KeyUp near 4 s", events[1])
+	}
+
+	// Без субтона одна речь не дает срабатываний
+	gen.Amplitude = 0
+	speech, _ := gen.Generate()
+	detector.Reset()
+	if events := detector.Process(speech); len(events) != 0 {
+		t.Errorf("speech alone produced events %+v", events)
+	}
+	if evs := detector.Flush(); len(evs) != 0 {
+		t.Errorf("Flush without active tone produced %+v", evs)
+	}
+
+	if _, err := NewCTCSSDetector(CTCSSConfig{SampleRate: 8000, BlockSize: 3200, Tones: []float64{100}, MinToneDuration: 1, MinPauseDuration: 1}); err == nil {
+		t.Error("expected error for single-tone list")
+	}
+}
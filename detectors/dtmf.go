@@ -0,0 +1,213 @@
+package detectors
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Alexxtn105/dsp/filters"
+	"github.com/Alexxtn105/dsp/generators"
+)
+
+// DTMFConfig конфигурация DTMF-декодера
+type DTMFConfig struct {
+	SampleRate          float64 // Частота дискретизации (Гц), не менее 8000 для проверки гармоник
+	BlockSize           int     // Длина блока анализа в отсчетах
+	MinLevel            float64 // Минимальная амплитуда каждого тона
+	MaxHighTwistDB      float64 // Допустимое превышение уровня верхней группы над нижней, дБ
+	MaxLowTwistDB       float64 // Допустимое превышение уровня нижней группы над верхней, дБ
+	MinRelativeDB       float64 // Минимальное превышение тона над остальными тонами своей группы, дБ
+	MaxSecondHarmonicDB float64 // Максимальный уровень второй гармоники относительно основного тона, дБ
+	MinEnergyRatio      float64 // Минимальная доля энергии блока, приходящаяся на пару тонов
+	MinToneDuration     float64 // Минимальная гарантированно принимаемая длительность тона, с
+	MinPauseDuration    float64 // Минимальная длительность паузы между символами, с
+}
+
+// DefaultDTMFConfig возвращает конфигурацию, близкую к требованиям ITU-T Q.24
+// Блок анализа около 12.8 мс, тоны от 40 мс, паузы от 40 мс, перекос +4/-8 дБ.
+func DefaultDTMFConfig(sampleRate float64) DTMFConfig {
+	return DTMFConfig{
+		SampleRate:          sampleRate,
+		BlockSize:           int(math.Round(0.0128 * sampleRate)),
+		MinLevel:            0.01,
+		MaxHighTwistDB:      4,
+		MaxLowTwistDB:       8,
+		MinRelativeDB:       6,
+		MaxSecondHarmonicDB: -15,
+		MinEnergyRatio:      0.5,
+		MinToneDuration:     0.040,
+		MinPauseDuration:    0.040,
+	}
+}
+
+// validate проверяет корректность конфигурации
+func (c DTMFConfig) validate() error {
+	if c.SampleRate <= 2*dtmfMaxFrequency {
+		return fmt.Errorf("sample rate must exceed %.0f Hz, got %f", 2*dtmfMaxFrequency, c.SampleRate)
+	}
+	if c.BlockSize < 16 {
+		return fmt.Errorf("block size must be at least 16 samples, got %d", c.BlockSize)
+	}
+	if c.MinLevel < 0 || c.MinEnergyRatio < 0 || c.MinEnergyRatio > 1 {
+		return fmt.Errorf("invalid level thresholds")
+	}
+	if c.MinToneDuration <= 0 || c.MinPauseDuration <= 0 {
+		return fmt.Errorf("tone and pause durations must be positive")
+	}
+	return nil
+}
+
+// Таблицы частот и раскладка определены в пакете generators (копии массивов)
+var (
+	dtmfLow    = generators.DTMFLowFrequencies
+	dtmfHigh   = generators.DTMFHighFrequencies
+	dtmfKeypad = generators.DTMFKeypad
+
+	// dtmfMaxFrequency - наибольшая частота DTMF (Гц)
+	dtmfMaxFrequency = dtmfHigh[len(dtmfHigh)-1]
+)
+
+// DTMFDecoder реализует потоковый DTMF-декодер на основе банка фильтров Герцеля
+// Каждый блок проверяется на уровень, перекос, относительный уровень тонов в группах,
+// вторые гармоники (защита от речи) и долю энергии пары тонов; затем логика
+// длительностей формирует события нажатия и отпускания с отметками времени.
+type DTMFDecoder struct {
+	config      DTMFConfig
+	bank        *filters.GoertzelBank
+	harmonics   bool           // Проверяются ли вторые гармоники (ниже частоты Найквиста)
+	rowHarmonic [4][4]bool     // Проверка гармоники строки для пары [строка][столбец]
+	sequencer   *toneSequencer // Логика длительностей
+}
+
+// NewDTMFDecoder создает DTMF-декодер с заданной конфигурацией
+func NewDTMFDecoder(config DTMFConfig) (*DTMFDecoder, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	freqs := make([]float64, 0, 16)
+	freqs = append(freqs, dtmfLow[:]...)
+	freqs = append(freqs, dtmfHigh[:]...)
+
+	harmonics := 2*2*dtmfMaxFrequency < config.SampleRate
+	if harmonics {
+		for _, f := range freqs[:8] {
+			freqs = append(freqs, 2*f)
+		}
+	}
+
+	bank, err := filters.NewGoertzelBank(freqs, config.SampleRate, config.BlockSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// Вторая гармоника тона строки, отстоящая от тона столбца меньше чем на полтора бина,
+	// неотличима от его утечки, поэтому для таких пар не проверяется
+	var rowHarmonic [4][4]bool
+	resolution := config.SampleRate / float64(config.BlockSize)
+	for row, low := range dtmfLow {
+		for col, high := range dtmfHigh {
+			rowHarmonic[row][col] = math.Abs(2*low-high) >= 1.5*resolution
+		}
+	}
+
+	return &DTMFDecoder{
+		config:      config,
+		bank:        bank,
+		harmonics:   harmonics,
+		rowHarmonic: rowHarmonic,
+		sequencer:   newToneSequencer(config.SampleRate, config.BlockSize, config.MinToneDuration, config.MinPauseDuration),
+	}, nil
+}
+
+// Process обрабатывает очередную порцию отсчетов и возвращает сформированные события
+func (d *DTMFDecoder) Process(samples []float64) []ToneEvent {
+	return toToneEvents(d.sequencer.process(samples, d.analyzeBlock), d.config.SampleRate)
+}
+
+// analyzeBlock анализирует блок и возвращает код символа или 0
+func (d *DTMFDecoder) analyzeBlock(block []float64) int {
+	result, err := d.bank.ProcessBlock(block)
+	if err != nil {
+		return 0
+	}
+	mags := result.Magnitudes
+
+	row := argMax(mags[0:4])
+	col := argMax(mags[4:8])
+	lowLevel := mags[row]
+	highLevel := mags[4+col]
+
+	// Абсолютный уровень
+	if lowLevel < d.config.MinLevel || highLevel < d.config.MinLevel {
+		return 0
+	}
+
+	// Перекос уровней групп
+	twist := toDB(highLevel / lowLevel)
+	if twist > d.config.MaxHighTwistDB || twist < -d.config.MaxLowTwistDB {
+		return 0
+	}
+
+	// Относительный уровень внутри групп
+	for i := 0; i < 4; i++ {
+		if i != row && toDB(lowLevel/mags[i]) < d.config.MinRelativeDB {
+			return 0
+		}
+		if i != col && toDB(highLevel/mags[4+i]) < d.config.MinRelativeDB {
+			return 0
+		}
+	}
+
+	// Вторые гармоники (речь и музыка имеют богатый гармонический состав)
+	if d.harmonics {
+		if (d.rowHarmonic[row][col] && toDB(mags[8+row]/lowLevel) > d.config.MaxSecondHarmonicDB) ||
+			toDB(mags[12+col]/highLevel) > d.config.MaxSecondHarmonicDB {
+			return 0
+		}
+	}
+
+	// Доля энергии блока, приходящаяся на пару тонов
+	var blockPower float64
+	for _, x := range block {
+		blockPower += x * x
+	}
+	blockPower /= float64(len(block))
+	tonePower := (lowLevel*lowLevel + highLevel*highLevel) / 2
+	if blockPower == 0 || tonePower/blockPower < d.config.MinEnergyRatio {
+		return 0
+	}
+
+	return int(dtmfKeypad[row][col])
+}
+
+// Flush завершает поток: если символ нажат, формирует событие отпускания
+func (d *DTMFDecoder) Flush() []ToneEvent {
+	return toToneEvents(d.sequencer.flush(), d.config.SampleRate)
+}
+
+// Reset сбрасывает состояние декодера
+func (d *DTMFDecoder) Reset() {
+	d.sequencer.reset()
+}
+
+// IsKeyDown возвращает нажатый в данный момент символ и признак нажатия
+func (d *DTMFDecoder) IsKeyDown() (rune, bool) {
+	code, down := d.sequencer.active()
+	return rune(code), down
+}
+
+// DecodeDTMF декодирует весь сигнал с конфигурацией по умолчанию и возвращает строку символов
+func DecodeDTMF(samples []float64, sampleRate float64) (string, error) {
+	decoder, err := NewDTMFDecoder(DefaultDTMFConfig(sampleRate))
+	if err != nil {
+		return "", err
+	}
+
+	var digits []rune
+	for _, e := range decoder.Process(samples) {
+		if e.Type == KeyDown {
+			digits = append(digits, e.Symbol)
+		}
+	}
+	return string(digits), nil
+}
@@ -0,0 +1,203 @@
+package detectors
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Alexxtn105/dsp/generators"
+)
+
+// decodeWith генерирует сигнал и возвращает строку нажатых символов
+func decodeWith(t *testing.T, gen *generators.DTMFGenerator, digits string) string {
+	t.Helper()
+	signal, err := gen.Generate(digits)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	decoded, err := DecodeDTMF(signal, gen.SampleRate)
+	if err != nil {
+		t.Fatalf("DecodeDTMF failed: %v", err)
+	}
+	return decoded
+}
+
+func TestDTMFDecoderAllDigits(t *testing.T) {
+	digits := "123A456B789C*0#D"
+	for _, fs := range []float64{8000, 16000} {
+		gen := generators.NewDTMFGenerator()
+		gen.SampleRate = fs
+		gen.LeadingSilence = 0.013
+		if got := decodeWith(t, gen, digits); got != digits {
+			t.Errorf("fs=%.0f: decoded %q, want %q", fs, got, digits)
+		}
+	}
+}
+
+// TestDTMFDecoderTwist проверяет границы допустимого перекоса уровней (+4/-8 дБ)
+func TestDTMFDecoderTwist(t *testing.T) {
+	tests := []struct {
+		twistDB float64
+		accept  bool
+	}{
+		{0, true},
+		{3, true},
+		{-7, true},
+		{7, false},
+		{-12, false},
+	}
+
+	for _, tt := range tests {
+		gen := generators.NewDTMFGenerator()
+		gen.TwistDB = tt.twistDB
+		got := decodeWith(t, gen, "5")
+		if tt.accept && got != "5" {
+			t.Errorf("twist %.0f dB: decoded %q, want \"5\"", tt.twistDB, got)
+		}
+		if !tt.accept && got != "" {
+			t.Errorf("twist %.0f dB: decoded %q, want rejection", tt.twistDB, got)
+		}
+	}
+}
+
+// TestDTMFDecoderFrequencyDeviation проверяет прием при отклонении частот ±1.5%
+func TestDTMFDecoderFrequencyDeviation(t *testing.T) {
+	for _, dev := range []float64{-0.015, 0.015} {
+		gen := generators.NewDTMFGenerator()
+		gen.FrequencyDeviation = dev
+		if got := decodeWith(t, gen, "159D"); got != "159D" {
+			t.Errorf("deviation %.3f: decoded %q, want \"159D\"", dev, got)
+		}
+	}
+}
+
+// TestDTMFDecoderTiming проверяет минимальные длительности тона и паузы
+func TestDTMFDecoderTiming(t *testing.T) {
+	gen := generators.NewDTMFGenerator()
+	gen.ToneDuration = 0.040
+	gen.PauseDuration = 0.040
+	if got := decodeWith(t, gen, "1122"); got != "1122" {
+		t.Errorf("40 ms tones/pauses: decoded %q, want \"1122\"", got)
+	}
+
+	gen = generators.NewDTMFGenerator()
+	gen.ToneDuration = 0.020
+	if got := decodeWith(t, gen, "123"); got != "" {
+		t.Errorf("20 ms tones should be rejected, decoded %q", got)
+	}
+
+	// Короткий разрыв внутри посылки не порождает повторного нажатия
+	gen = generators.NewDTMFGenerator()
+	gen.ToneDuration = 0.060
+	gen.PauseDuration = 0.010
+	if got := decodeWith(t, gen, "77"); got != "7" {
+		t.Errorf("10 ms gap should be bridged, decoded %q", got)
+	}
+}
+
+// TestDTMFDecoderNoise проверяет прием на фоне шума
+func TestDTMFDecoderNoise(t *testing.T) {
+	gen := generators.NewDTMFGenerator()
+	gen.NoiseAmplitude = 0.05
+	gen.Seed = 7
+	if got := decodeWith(t, gen, "0123456789"); got != "0123456789" {
+		t.Errorf("decoded %q in noise, want \"0123456789\"", got)
+	}
+
+	// Чистый шум не должен давать срабатываний
+	gen.Amplitude = 1e-9
+	if got := decodeWith(t, gen, "0123456789"); got != "" {
+		t.Errorf("noise alone decoded as %q", got)
+	}
+}
+
+// TestDTMFDecoderHarmonics проверяет отбраковку сигнала с сильными вторыми гармониками
+func TestDTMFDecoderHarmonics(t *testing.T) {
+	fs := 8000.0
+	signal := make([]float64, 800)
+	for n := range signal {
+		ti := float64(n) / fs
+		signal[n] = 0.4*math.Sin(2*math.Pi*770*ti) + 0.4*math.Sin(2*math.Pi*1336*ti) +
+			0.2*math.Sin(2*math.Pi*1540*ti) + 0.2*math.Sin(2*math.Pi*2672*ti)
+	}
+	decoded, err := DecodeDTMF(signal, fs)
+	if err != nil {
+		t.Fatalf("DecodeDTMF failed: %v", err)
+	}
+	if decoded != "" {
+		t.Errorf("signal with strong harmonics decoded as %q", decoded)
+	}
+}
+
+// TestDTMFDecoderEvents проверяет события и отметки времени в потоковом режиме
+func TestDTMFDecoderEvents(t *testing.T) {
+	gen := generators.NewDTMFGenerator()
+	gen.LeadingSilence = 0.1
+	gen.ToneDuration = 0.1
+	gen.PauseDuration = 0.1
+	signal, _ := gen.Generate("9#")
+
+	decoder, err := NewDTMFDecoder(DefaultDTMFConfig(gen.SampleRate))
+	if err != nil {
+		t.Fatalf("NewDTMFDecoder failed: %v", err)
+	}
+
+	// Подача сигнала порциями произвольной длины
+	var events []ToneEvent
+	for start := 0; start < len(signal); start += 37 {
+		end := min(start+37, len(signal))
+		events = append(events, decoder.Process(signal[start:end])...)
+	}
+	events = append(events, decoder.Flush()...)
+
+	want := []struct {
+		typ    ToneEventType
+		symbol rune
+		time   float64
+	}{
+		{KeyDown, '9', 0.1},
+		{KeyUp, '9', 0.2},
+		{KeyDown, '#', 0.3},
+		{KeyUp, '#', 0.4},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %d: %+v", len(want), len(events), events)
+	}
+
+	blockTime := 0.0128
+	for i, w := range want {
+		e := events[i]
+		if e.Type != w.typ || e.Symbol != w.symbol {
+			t.Errorf("event %d: got %v %q, want %v %q", i, e.Type, e.Symbol, w.typ, w.symbol)
+		}
+		if math.Abs(e.Time-w.time) > blockTime+1e-9 {
+			t.Errorf("event %d: time %.4f, want %.4f ± %.4f", i, e.Time, w.time, blockTime)
+		}
+		if e.Time != float64(e.Sample)/gen.SampleRate {
+			t.Errorf("event %d: time and sample index disagree", i)
+		}
+	}
+
+	if _, down := decoder.IsKeyDown(); down {
+		t.Error("key should be released after Flush")
+	}
+	decoder.Reset()
+	if evs := decoder.Process(signal); len(evs) != 4 {
+		t.Errorf("expected 4 events after Reset, got %d", len(evs))
+	}
+}
+
+func TestDTMFDecoderConfigErrors(t *testing.T) {
+	if _, err := NewDTMFDecoder(DefaultDTMFConfig(3000)); err == nil {
+		t.Error("expected error for sample rate below Nyquist")
+	}
+	cfg := DefaultDTMFConfig(8000)
+	cfg.BlockSize = 4
+	if _, err := NewDTMFDecoder(cfg); err == nil {
+		t.Error("expected error for tiny block size")
+	}
+	cfg = DefaultDTMFConfig(8000)
+	cfg.MinToneDuration = 0
+	if _, err := NewDTMFDecoder(cfg); err == nil {
+		t.Error("expected error for zero tone duration")
+	}
+}
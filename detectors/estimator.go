@@ -0,0 +1,63 @@
+package detectors
+
+import "fmt"
+
+// FrequencyEstimator - общий интерфейс потоковых оценщиков частоты комплексного сигнала
+// Реализуется FrequencyDetector и PLLFrequencyDetector; новые оценщики должны
+// реализовывать этот интерфейс, чтобы их можно было взаимозаменять.
+type FrequencyEstimator interface {
+	// DetectFrequency обрабатывает один комплексный отсчет и возвращает оценку частоты (Гц)
+	DetectFrequency(signal complex128) float64
+
+	// ProcessBlock обрабатывает блок отсчетов и возвращает оценку частоты для каждого из них
+	ProcessBlock(signals []complex128) []float64
+
+	// Reset сбрасывает внутреннее состояние
+	Reset()
+
+	// GetCurrentFrequency возвращает текущую оценку частоты (Гц)
+	GetCurrentFrequency() float64
+}
+
+// Проверка соответствия интерфейсу на этапе компиляции
+var (
+	_ FrequencyEstimator = (*FrequencyDetector)(nil)
+	_ FrequencyEstimator = (*PLLFrequencyDetector)(nil)
+)
+
+// Validate проверяет конфигурацию детектора
+// SmoothingFactor должен лежать в [0, 1] (0 - без сглаживания),
+// PLLBandwidth - в [0, SampleRate/2) (0 - 1% частоты дискретизации).
+func (c FrequencyDetectorConfig) Validate() error {
+	if c.SampleRate <= 0 {
+		return fmt.Errorf("sample rate must be positive, got %f", c.SampleRate)
+	}
+	if c.SmoothingFactor < 0 || c.SmoothingFactor > 1 {
+		return fmt.Errorf("smoothing factor must be in [0, 1], got %f", c.SmoothingFactor)
+	}
+	if c.PLLBandwidth < 0 || c.PLLBandwidth >= c.SampleRate/2 {
+		return fmt.Errorf("PLL bandwidth must be in [0, %f), got %f", c.SampleRate/2, c.PLLBandwidth)
+	}
+	return nil
+}
+
+// NewFrequencyEstimator создает оценщик частоты по конфигурации
+// В отличие от NewFrequencyDetectorWithConfig возвращает интерфейс FrequencyEstimator
+// и ошибку при некорректных параметрах вместо паники.
+func NewFrequencyEstimator(config FrequencyDetectorConfig) (FrequencyEstimator, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	if config.UsePLL {
+		bandwidth := config.PLLBandwidth
+		if bandwidth == 0 {
+			bandwidth = config.SampleRate / 100 // 1% от частоты дискретизации по умолчанию
+		}
+		return NewPLLFrequencyDetector(config.SampleRate, bandwidth), nil
+	}
+
+	detector := NewFrequencyDetector(config.SampleRate)
+	detector.SetSmoothingFactor(config.SmoothingFactor)
+	return detector, nil
+}
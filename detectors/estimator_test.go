@@ -0,0 +1,66 @@
+package detectors
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// TestNewFrequencyEstimator проверяет создание оценщиков через общий интерфейс
+func TestNewFrequencyEstimator(t *testing.T) {
+	fs := 48000.0
+	configs := []FrequencyDetectorConfig{
+		{SampleRate: fs, SmoothingFactor: 0.2},
+		{SampleRate: fs, SmoothingFactor: 0},
+		{SampleRate: fs, UsePLL: true, PLLBandwidth: 500},
+		{SampleRate: fs, UsePLL: true},
+	}
+
+	signals := make([]complex128, 4000)
+	for i := range signals {
+		signals[i] = cmplx.Exp(complex(0, 2*math.Pi*1000*float64(i)/fs))
+	}
+
+	for _, config := range configs {
+		estimator, err := NewFrequencyEstimator(config)
+		if err != nil {
+			t.Fatalf("%+v: NewFrequencyEstimator failed: %v", config, err)
+		}
+
+		frequencies := estimator.ProcessBlock(signals)
+		if len(frequencies) != len(signals) {
+			t.Fatalf("%+v: expected %d estimates, got %d", config, len(signals), len(frequencies))
+		}
+		if f := estimator.GetCurrentFrequency(); math.Abs(f-1000) > 1 {
+			t.Errorf("%+v: current frequency %f, want 1000", config, f)
+		}
+		if f := frequencies[len(frequencies)-1]; math.Abs(f-estimator.GetCurrentFrequency()) > 1e-9 {
+			t.Errorf("%+v: last estimate %f differs from current %f", config, f, estimator.GetCurrentFrequency())
+		}
+
+		estimator.Reset()
+		if f := estimator.GetCurrentFrequency(); f != 0 {
+			t.Errorf("%+v: frequency after reset %f, want 0", config, f)
+		}
+		if f := estimator.DetectFrequency(signals[0]); math.IsNaN(f) {
+			t.Errorf("%+v: NaN after reset", config)
+		}
+	}
+}
+
+// TestNewFrequencyEstimatorErrors проверяет валидацию конфигурации
+func TestNewFrequencyEstimatorErrors(t *testing.T) {
+	invalid := []FrequencyDetectorConfig{
+		{SampleRate: 0},
+		{SampleRate: -1, UsePLL: true},
+		{SampleRate: 8000, SmoothingFactor: -0.1},
+		{SampleRate: 8000, SmoothingFactor: 1.5},
+		{SampleRate: 8000, UsePLL: true, PLLBandwidth: -10},
+		{SampleRate: 8000, UsePLL: true, PLLBandwidth: 4000},
+	}
+	for _, config := range invalid {
+		if estimator, err := NewFrequencyEstimator(config); err == nil || estimator != nil {
+			t.Errorf("%+v: expected error", config)
+		}
+	}
+}
@@ -14,6 +14,7 @@ type FrequencyDetector struct {
 	alpha             float64 // Коэффициент сглаживания (для усреднения)
 	smoothedFreq      float64 // Текущее сглаженное значение частоты
 	smoothInitialized bool    // Флаг инициализации сглаживания
+	currentFreq       float64 // Последняя оценка частоты
 }
 
 // NewFrequencyDetector создает новый частотный детектор
@@ -73,6 +74,7 @@ func (fd *FrequencyDetector) DetectFrequency(signal complex128) float64 {
 	// Обновление состояния
 	fd.prevSignal = signal
 	// Фаза обновляется в computePhaseDifference через unwrapPhaseDiff
+	fd.currentFreq = instantaneousFreq
 
 	return instantaneousFreq
 }
@@ -106,6 +108,12 @@ func (fd *FrequencyDetector) Reset() {
 	fd.unwrapOffset = 0
 	fd.smoothedFreq = 0
 	fd.smoothInitialized = false
+	fd.currentFreq = 0
+}
+
+// GetCurrentFrequency возвращает последнюю оценку частоты в Гц (со сглаживанием, если оно включено)
+func (fd *FrequencyDetector) GetCurrentFrequency() float64 {
+	return fd.currentFreq
 }
 
 // GetInstantaneousPhase возвращает текущую мгновенную фазу
@@ -204,16 +212,27 @@ func (fd *FrequencyDetector) GetSmoothedFrequency() float64 {
 }
 
 // PLLFrequencyDetector - альтернативная реализация на основе фазовой автоподстройки частоты (PLL)
+// Порядок петли, коэффициент затухания и параметры детектора захвата задаются PLLConfig.
 type PLLFrequencyDetector struct {
 	sampleRate float64
 	phase      float64
 	frequency  float64 // Нормализованная частота (радиан/сэмпл)
+	rate       float64 // Скорость изменения частоты (радиан/сэмпл²), только для петли 3-го порядка
 	alpha      float64 // Коэффициент петли для фазы
 	beta       float64 // Коэффициент петли для частоты
+	gamma      float64 // Коэффициент петли для скорости изменения частоты (3-й порядок)
 	bandwidth  float64 // Полоса пропускания
+	damping    float64 // Коэффициент затухания
+	order      int     // Порядок петли (2 или 3)
+
+	lock pllLockDetector // Детектор захвата
 }
 
 // NewPLLFrequencyDetector создает частотный детектор на основе PLL
+// Петля 2-го порядка с коэффициентом затухания 0.707; для других параметров
+// используйте NewPLLFrequencyDetectorWithConfig.
+// Верхняя граница полосы не проверяется (как и раньше); строгая проверка параметров
+// выполняется только в NewPLLFrequencyDetectorWithConfig.
 func NewPLLFrequencyDetector(sampleRate, bandwidth float64) *PLLFrequencyDetector {
 	if sampleRate <= 0 {
 		panic("sampleRate must be positive")
@@ -222,24 +241,7 @@ func NewPLLFrequencyDetector(sampleRate, bandwidth float64) *PLLFrequencyDetecto
 		panic("bandwidth must be positive")
 	}
 
-	// Расчет коэффициентов для критического затухания
-	damping := 0.707                                    // Коэффициент затухания
-	naturalFreq := 2 * math.Pi * bandwidth / sampleRate // Нормализованная собственная частота
-
-	// Дискретные коэффициенты для петли 2-го порядка
-	alpha := (4 * damping * naturalFreq) /
-		(4 + 4*damping*naturalFreq + math.Pow(naturalFreq, 2))
-	beta := (4 * math.Pow(naturalFreq, 2)) /
-		(4 + 4*damping*naturalFreq + math.Pow(naturalFreq, 2))
-
-	return &PLLFrequencyDetector{
-		sampleRate: sampleRate,
-		phase:      0,
-		frequency:  0,
-		alpha:      alpha,
-		beta:       beta,
-		bandwidth:  bandwidth,
-	}
+	return newPLLFrequencyDetector(DefaultPLLConfig(sampleRate, bandwidth))
 }
 
 // SetBandwidth устанавливает новую полосу пропускания PLL
@@ -249,17 +251,18 @@ func (pll *PLLFrequencyDetector) SetBandwidth(bandwidth float64) {
 	}
 
 	pll.bandwidth = bandwidth
-	naturalFreq := 2 * math.Pi * bandwidth / pll.sampleRate
-	damping := 0.707
-
-	pll.alpha = (4 * damping * naturalFreq) /
-		(4 + 4*damping*naturalFreq + math.Pow(naturalFreq, 2))
-	pll.beta = (4 * math.Pow(naturalFreq, 2)) /
-		(4 + 4*damping*naturalFreq + math.Pow(naturalFreq, 2))
+	pll.updateLoopCoefficients()
 }
 
 // DetectFrequencyPLL использует PLL для оценки частоты
+//
+// Deprecated: используйте DetectFrequency.
 func (pll *PLLFrequencyDetector) DetectFrequencyPLL(signal complex128) float64 {
+	return pll.DetectFrequency(signal)
+}
+
+// DetectFrequency использует PLL для оценки частоты
+func (pll *PLLFrequencyDetector) DetectFrequency(signal complex128) float64 {
 	// Нормализация входного сигнала
 	magnitude := cmplx.Abs(signal)
 	if magnitude > 1e-10 { // Маленький порог для устойчивости
@@ -280,7 +283,10 @@ func (pll *PLLFrequencyDetector) DetectFrequencyPLL(signal complex128) float64 {
 
 	// Обновление фазы и частоты через петлю фильтра
 	pll.phase += pll.frequency + pll.alpha*phaseError
-	pll.frequency += pll.beta * phaseError
+	pll.frequency += pll.rate + pll.beta*phaseError
+	if pll.order == 3 {
+		pll.rate += pll.gamma * phaseError
+	}
 
 	// Ограничение частоты для устойчивости
 	pll.limitNormalizedFrequency()
@@ -288,6 +294,9 @@ func (pll *PLLFrequencyDetector) DetectFrequencyPLL(signal complex128) float64 {
 	// Нормализация фазы
 	pll.normalizePhase()
 
+	// Обновление детектора захвата
+	pll.lock.update(phaseError, pll.GetCurrentFrequency())
+
 	// Мгновенная частота в Гц
 	instantaneousFreq := pll.frequency * pll.sampleRate / (2 * math.Pi)
 
@@ -297,12 +306,12 @@ func (pll *PLLFrequencyDetector) DetectFrequencyPLL(signal complex128) float64 {
 
 // limitNormalizedFrequency ограничивает нормализованную частоту
 func (pll *PLLFrequencyDetector) limitNormalizedFrequency() {
-	maxNormalizedFreq := 0.5 // половина частоты дискретизации в нормализованном виде
-
-	if pll.frequency > maxNormalizedFreq {
-		pll.frequency = maxNormalizedFreq
-	} else if pll.frequency < -maxNormalizedFreq {
-		pll.frequency = -maxNormalizedFreq
+	if pll.frequency > pllMaxNormalizedFrequency {
+		pll.frequency = pllMaxNormalizedFrequency
+		pll.rate = 0 // Исключаем накопление в интеграторе 3-го порядка
+	} else if pll.frequency < -pllMaxNormalizedFrequency {
+		pll.frequency = -pllMaxNormalizedFrequency
+		pll.rate = 0
 	}
 }
 
@@ -326,20 +335,36 @@ func (pll *PLLFrequencyDetector) limitFrequency(freq float64) float64 {
 }
 
 // ProcessBlockPLL обрабатывает блок данных с использованием PLL
+//
+// Deprecated: используйте ProcessBlock.
 func (pll *PLLFrequencyDetector) ProcessBlockPLL(signals []complex128) []float64 {
+	return pll.ProcessBlock(signals)
+}
+
+// ProcessBlock обрабатывает блок данных с использованием PLL
+func (pll *PLLFrequencyDetector) ProcessBlock(signals []complex128) []float64 {
 	frequencies := make([]float64, len(signals))
 
 	for i, signal := range signals {
-		frequencies[i] = pll.DetectFrequencyPLL(signal)
+		frequencies[i] = pll.DetectFrequency(signal)
 	}
 
 	return frequencies
 }
 
 // ResetPLL сбрасывает состояние PLL детектора
+//
+// Deprecated: используйте Reset.
 func (pll *PLLFrequencyDetector) ResetPLL() {
+	pll.Reset()
+}
+
+// Reset сбрасывает состояние PLL детектора
+func (pll *PLLFrequencyDetector) Reset() {
 	pll.phase = 0
 	pll.frequency = 0
+	pll.rate = 0
+	pll.lock.reset()
 }
 
 // GetCurrentPhase возвращает текущую фазу PLL
@@ -366,6 +391,9 @@ type FrequencyDetectorConfig struct {
 }
 
 // NewFrequencyDetectorWithConfig создает детектор с конфигурацией
+//
+// Deprecated: используйте NewFrequencyEstimator, который возвращает интерфейс
+// FrequencyEstimator и ошибку вместо паники.
 func NewFrequencyDetectorWithConfig(config FrequencyDetectorConfig) interface{} {
 	if config.SampleRate <= 0 {
 		panic("SampleRate must be positive")
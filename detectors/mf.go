@@ -0,0 +1,255 @@
+package detectors
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Alexxtn105/dsp/filters"
+	"github.com/Alexxtn105/dsp/generators"
+)
+
+// MFStandard определяет систему многочастотной сигнализации
+// Тип, частоты и таблицы символов определены в пакете generators и общие для генератора и декодера.
+type MFStandard = generators.MFStandard
+
+const (
+	MFR1         = generators.MFR1         // R1 (ITU-T Q.320, Bell MF): 700-1700 Гц
+	MFR2Forward  = generators.MFR2Forward  // R2, прямое направление (ITU-T Q.441): 1380-1980 Гц
+	MFR2Backward = generators.MFR2Backward // R2, обратное направление: 1140-540 Гц
+)
+
+// Символы по парам индексов частот [i][j], i < j
+var (
+	mfR1Pairs = mfPairTable(generators.MFR1Symbols)
+	mfR2Pairs = mfPairTable(generators.MFR2Symbols)
+)
+
+// mfPairTable строит таблицу символов по парам индексов частот из таблицы пар символов
+func mfPairTable(symbols map[rune][2]int) [6][6]rune {
+	var table [6][6]rune
+	for symbol, pair := range symbols {
+		i, j := min(pair[0], pair[1]), max(pair[0], pair[1])
+		table[i][j] = symbol
+	}
+	return table
+}
+
+// MFConfig конфигурация декодера MF R1/R2
+type MFConfig struct {
+	Standard          MFStandard // Система сигнализации
+	SampleRate        float64    // Частота дискретизации (Гц)
+	BlockSize         int        // Длина блока анализа в отсчетах
+	MinLevel          float64    // Минимальная амплитуда каждого тона
+	MaxTwistDB        float64    // Максимальная разность уровней двух тонов, дБ
+	MinRelativeDB     float64    // Минимальное превышение слабого тона пары над третьим по уровню, дБ
+	MinEnergyRatio    float64    // Минимальная доля энергии блока, приходящаяся на пару тонов
+	RelativeTolerance float64    // Допуск на отклонение частоты, доля номинала
+	AbsoluteTolerance float64    // Дополнительный допуск на отклонение частоты, Гц
+	MinToneDuration   float64    // Минимальная гарантированно принимаемая длительность тона, с
+	MinPauseDuration  float64    // Минимальная длительность паузы между сигналами, с
+}
+
+// DefaultMFConfig возвращает типовую конфигурацию для заданной системы сигнализации
+// R1: блок 20 мс, допуск ±1.5% ±5 Гц, тоны и паузы от 40 мс;
+// R2: блок 25 мс, допуск ±10 Гц, тоны и паузы от 50 мс.
+func DefaultMFConfig(standard MFStandard, sampleRate float64) MFConfig {
+	config := MFConfig{
+		Standard:          standard,
+		SampleRate:        sampleRate,
+		BlockSize:         int(math.Round(0.020 * sampleRate)),
+		MinLevel:          0.01,
+		MaxTwistDB:        6,
+		MinRelativeDB:     10,
+		MinEnergyRatio:    0.5,
+		RelativeTolerance: 0.015,
+		AbsoluteTolerance: 5,
+		MinToneDuration:   0.040,
+		MinPauseDuration:  0.040,
+	}
+	if standard != MFR1 {
+		config.BlockSize = int(math.Round(0.025 * sampleRate))
+		config.RelativeTolerance = 0
+		config.AbsoluteTolerance = 10
+		config.MinToneDuration = 0.050
+		config.MinPauseDuration = 0.050
+	}
+	return config
+}
+
+// mfTables возвращает таблицы частот и символов системы сигнализации
+func mfTables(s MFStandard) ([6]float64, *[6][6]rune, error) {
+	switch s {
+	case MFR1:
+		return generators.MFR1Frequencies, &mfR1Pairs, nil
+	case MFR2Forward:
+		return generators.MFR2ForwardFrequencies, &mfR2Pairs, nil
+	case MFR2Backward:
+		return generators.MFR2BackwardFrequencies, &mfR2Pairs, nil
+	default:
+		return [6]float64{}, nil, fmt.Errorf("unknown MF standard: %d", s)
+	}
+}
+
+// validate проверяет корректность конфигурации
+func (c MFConfig) validate() error {
+	if c.SampleRate <= 0 {
+		return fmt.Errorf("sample rate must be positive, got %f", c.SampleRate)
+	}
+	if c.BlockSize < 16 {
+		return fmt.Errorf("block size must be at least 16 samples, got %d", c.BlockSize)
+	}
+	if c.MinLevel < 0 || c.MinEnergyRatio < 0 || c.MinEnergyRatio > 1 {
+		return fmt.Errorf("invalid level thresholds")
+	}
+	if c.RelativeTolerance < 0 || c.AbsoluteTolerance < 0 {
+		return fmt.Errorf("frequency tolerances must be non-negative")
+	}
+	if c.MinToneDuration <= 0 || c.MinPauseDuration <= 0 {
+		return fmt.Errorf("tone and pause durations must be positive")
+	}
+	return nil
+}
+
+// MFDecoder реализует потоковый декодер сигналов MF R1/R2 ("2 из 6")
+// Для каждой номинальной частоты банк Герцеля содержит три бина с шагом fs/N,
+// по которым уточняются частота и амплитуда тона. Пара сильнейших тонов принимается,
+// если их частоты в пределах допуска, уровни сбалансированы, третий тон слаб,
+// а пара несет основную часть энергии блока.
+type MFDecoder struct {
+	config    MFConfig
+	freqs     [6]float64  // Номинальные частоты
+	pairs     *[6][6]rune // Символы по парам индексов
+	bank      *filters.GoertzelBank
+	step      float64        // Шаг между бинами одной частоты (Гц)
+	sequencer *toneSequencer // Логика длительностей
+}
+
+// NewMFDecoder создает декодер MF R1/R2 с заданной конфигурацией
+func NewMFDecoder(config MFConfig) (*MFDecoder, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	freqs, pairs, err := mfTables(config.Standard)
+	if err != nil {
+		return nil, err
+	}
+
+	step := config.SampleRate / float64(config.BlockSize)
+	bins := make([]float64, 0, 3*len(freqs))
+	for _, f := range freqs {
+		bins = append(bins, f-step, f, f+step)
+	}
+
+	bank, err := filters.NewGoertzelBank(bins, config.SampleRate, config.BlockSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MFDecoder{
+		config:    config,
+		freqs:     freqs,
+		pairs:     pairs,
+		bank:      bank,
+		step:      step,
+		sequencer: newToneSequencer(config.SampleRate, config.BlockSize, config.MinToneDuration, config.MinPauseDuration),
+	}, nil
+}
+
+// Process обрабатывает очередную порцию отсчетов и возвращает сформированные события
+func (d *MFDecoder) Process(samples []float64) []ToneEvent {
+	return toToneEvents(d.sequencer.process(samples, d.analyzeBlock), d.config.SampleRate)
+}
+
+// analyzeBlock анализирует блок и возвращает код символа или 0
+func (d *MFDecoder) analyzeBlock(block []float64) int {
+	result, err := d.bank.ProcessBlock(block)
+	if err != nil {
+		return 0
+	}
+	mags := result.Magnitudes
+
+	var estimates, levels [6]float64
+	for i, f := range d.freqs {
+		estimates[i], levels[i] = interpolateTone(mags[3*i], mags[3*i+1], mags[3*i+2], f, d.step)
+	}
+
+	// Два сильнейших тона и третий по уровню
+	first := argMax(levels[:])
+	second := -1
+	for i := range levels {
+		if i != first && (second < 0 || levels[i] > levels[second]) {
+			second = i
+		}
+	}
+	var third float64
+	for i, level := range levels {
+		if i != first && i != second && level > third {
+			third = level
+		}
+	}
+
+	strong, weak := levels[first], levels[second]
+	if weak < d.config.MinLevel {
+		return 0
+	}
+	if toDB(strong/weak) > d.config.MaxTwistDB {
+		return 0
+	}
+	if toDB(weak/third) < d.config.MinRelativeDB {
+		return 0
+	}
+
+	// Допуск на отклонение частот
+	for _, i := range []int{first, second} {
+		tolerance := d.config.RelativeTolerance*d.freqs[i] + d.config.AbsoluteTolerance
+		if math.Abs(estimates[i]-d.freqs[i]) > tolerance {
+			return 0
+		}
+	}
+
+	// Доля энергии блока, приходящаяся на пару тонов
+	var blockPower float64
+	for _, x := range block {
+		blockPower += x * x
+	}
+	blockPower /= float64(len(block))
+	tonePower := (strong*strong + weak*weak) / 2
+	if blockPower == 0 || tonePower/blockPower < d.config.MinEnergyRatio {
+		return 0
+	}
+
+	i, j := min(first, second), max(first, second)
+	return int(d.pairs[i][j])
+}
+
+// Flush завершает поток: если сигнал активен, формирует событие его окончания
+func (d *MFDecoder) Flush() []ToneEvent {
+	return toToneEvents(d.sequencer.flush(), d.config.SampleRate)
+}
+
+// Reset сбрасывает состояние декодера
+func (d *MFDecoder) Reset() {
+	d.sequencer.reset()
+}
+
+// IsKeyDown возвращает активный в данный момент символ и признак его наличия
+func (d *MFDecoder) IsKeyDown() (rune, bool) {
+	code, down := d.sequencer.active()
+	return rune(code), down
+}
+
+// DecodeMF декодирует весь сигнал с конфигурацией по умолчанию и возвращает строку символов
+func DecodeMF(samples []float64, standard MFStandard, sampleRate float64) (string, error) {
+	decoder, err := NewMFDecoder(DefaultMFConfig(standard, sampleRate))
+	if err != nil {
+		return "", err
+	}
+
+	var symbols []rune
+	for _, e := range decoder.Process(samples) {
+		if e.Type == KeyDown {
+			symbols = append(symbols, e.Symbol)
+		}
+	}
+	return string(symbols), nil
+}
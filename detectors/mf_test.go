@@ -0,0 +1,121 @@
+package detectors
+
+import (
+	"testing"
+
+	"github.com/Alexxtn105/dsp/generators"
+)
+
+// mfStandards перечисляет системы сигнализации и их полные наборы символов
+var mfStandards = []struct {
+	standard MFStandard
+	symbols  string
+}{
+	{MFR1, "K1234567890SABC"},
+	{MFR2Forward, "123456789ABCDEF"},
+	{MFR2Backward, "123456789ABCDEF"},
+}
+
+func decodeMFWith(t *testing.T, gen *generators.MFGenerator, standard MFStandard, symbols string) string {
+	t.Helper()
+	signal, err := gen.Generate(symbols)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	decoded, err := DecodeMF(signal, standard, gen.SampleRate)
+	if err != nil {
+		t.Fatalf("DecodeMF failed: %v", err)
+	}
+	return decoded
+}
+
+func TestMFDecoderAllSymbols(t *testing.T) {
+	for _, s := range mfStandards {
+		gen := generators.NewMFGenerator(s.standard)
+		gen.LeadingSilence = 0.007
+		if got := decodeMFWith(t, gen, s.standard, s.symbols); got != s.symbols {
+			t.Errorf("%v: decoded %q, want %q", s.standard, got, s.symbols)
+		}
+	}
+}
+
+// TestMFDecoderTolerance проверяет прием в пределах допуска и отбраковку за его пределами
+func TestMFDecoderTolerance(t *testing.T) {
+	gen := generators.NewMFGenerator(generators.MFR1)
+	for _, dev := range []float64{-0.015, 0.015} {
+		gen.FrequencyDeviation = dev
+		if got := decodeMFWith(t, gen, MFR1, "K159S"); got != "K159S" {
+			t.Errorf("R1 deviation %.3f: decoded %q, want \"K159S\"", dev, got)
+		}
+	}
+	gen.FrequencyDeviation = 0.04
+	if got := decodeMFWith(t, gen, MFR1, "K159S"); got != "" {
+		t.Errorf("R1 deviation 4%%: decoded %q, want rejection", got)
+	}
+
+	// R2: допуск ±10 Гц, 0.5% на 1980 Гц - около 10 Гц
+	gen = generators.NewMFGenerator(generators.MFR2Forward)
+	gen.FrequencyDeviation = 0.004
+	if got := decodeMFWith(t, gen, MFR2Forward, "1F"); got != "1F" {
+		t.Errorf("R2 deviation 0.4%%: decoded %q, want \"1F\"", got)
+	}
+	gen.FrequencyDeviation = 0.015
+	if got := decodeMFWith(t, gen, MFR2Forward, "1F"); got != "" {
+		t.Errorf("R2 deviation 1.5%%: decoded %q, want rejection", got)
+	}
+}
+
+// TestMFDecoderTwistAndNoise проверяет перекос уровней и прием на фоне шума
+func TestMFDecoderTwistAndNoise(t *testing.T) {
+	gen := generators.NewMFGenerator(generators.MFR1)
+	gen.TwistDB = -5
+	if got := decodeMFWith(t, gen, MFR1, "K23S"); got != "K23S" {
+		t.Errorf("twist -5 dB: decoded %q", got)
+	}
+	gen.TwistDB = -10
+	if got := decodeMFWith(t, gen, MFR1, "K23S"); got != "" {
+		t.Errorf("twist -10 dB: decoded %q, want rejection", got)
+	}
+
+	gen = generators.NewMFGenerator(generators.MFR2Backward)
+	gen.NoiseAmplitude = 0.05
+	gen.Seed = 3
+	if got := decodeMFWith(t, gen, MFR2Backward, "15AF"); got != "15AF" {
+		t.Errorf("noise: decoded %q, want \"15AF\"", got)
+	}
+}
+
+// TestMFDecoderEvents проверяет события и отметки времени
+func TestMFDecoderEvents(t *testing.T) {
+	gen := generators.NewMFGenerator(generators.MFR1)
+	gen.LeadingSilence = 0.05
+	signal, _ := gen.Generate("K7")
+
+	decoder, err := NewMFDecoder(DefaultMFConfig(MFR1, gen.SampleRate))
+	if err != nil {
+		t.Fatalf("NewMFDecoder failed: %v", err)
+	}
+	events := decoder.Process(signal)
+	events = append(events, decoder.Flush()...)
+
+	if len(events) != 4 {
+		t.Fatalf("expected 4 events, got %d: %+v", len(events), events)
+	}
+	// KP 100 мс, пауза 68 мс, затем '7'
+	want := []float64{0.05, 0.15, 0.218, 0.286}
+	for i, e := range events {
+		if diff := e.Time - want[i]; diff < -0.021 || diff > 0.021 {
+			t.Errorf("event %d (%v %q): time %.3f, want %.3f", i, e.Type, e.Symbol, e.Time, want[i])
+		}
+	}
+	if events[0].Symbol != 'K' || events[2].Symbol != '7' || events[2].Type != KeyDown {
+		t.Errorf("unexpected event sequence: %+v", events)
+	}
+
+	if _, err := NewMFDecoder(MFConfig{Standard: MFStandard(9), SampleRate: 8000, BlockSize: 160, MinToneDuration: 1, MinPauseDuration: 1}); err == nil {
+		t.Error("expected error for unknown standard")
+	}
+	if _, err := NewMFDecoder(DefaultMFConfig(MFR1, 3000)); err == nil {
+		t.Error("expected error for frequencies above Nyquist")
+	}
+}
@@ -1,25 +1,38 @@
 package detectors
 
 import (
+	"fmt"
 	"math"
 	"math/cmplx"
 )
 
 // CoherentPhaseDetector представляет собой структуру фазового детектора
+// В режиме слежения опорный сигнал вращается с частотой NCO (числового генератора),
+// что позволяет измерять фазу относительно движущейся несущей.
 type CoherentPhaseDetector struct {
 	referenceSignal complex128 // Опорный сигнал (нормированный)
 	phaseOffset     float64    // Компенсационное смещение фазы
 	alpha           float64    // Коэффициент фильтрации (0 < alpha <= 1)
 	filteredError   float64    // Отфильтрованная ошибка фазы
+	squelch         float64    // Порог амплитуды входного сигнала, ниже которого отсчет игнорируется
+	squelched       bool       // Был ли подавлен последний отсчет
+	tracking        bool       // Режим слежения за вращающейся опорой
+	ncoPhase        float64    // Текущая фаза NCO (рад)
+	ncoStep         float64    // Приращение фазы NCO за отсчет (рад)
+	ncoFrequency    float64    // Частота NCO (Гц)
+	sampleRate      float64    // Частота дискретизации для NCO (Гц)
 }
 
 // NewCoherentPhaseDetector создает новый экземпляр фазового детектора
+// Если опорный сигнал нулевой или содержит NaN/Inf, используется опора с нулевой фазой.
 func NewCoherentPhaseDetector(referenceSignal complex128, alpha float64) *CoherentPhaseDetector {
 	// Нормируем опорный сигнал
-	refMagnitude := cmplx.Abs(referenceSignal)
-	refNorm := referenceSignal / complex(refMagnitude, 0)
+	refNorm, ok := normalizeReference(referenceSignal)
+	if !ok {
+		refNorm = 1
+	}
 
-	if alpha <= 0 || alpha > 1 {
+	if alpha <= 0 || alpha > 1 || math.IsNaN(alpha) {
 		alpha = 0.1 // значение по умолчанию
 	}
 
@@ -32,13 +45,25 @@ func NewCoherentPhaseDetector(referenceSignal complex128, alpha float64) *Cohere
 }
 
 // Detect измеряет и фильтрует ошибку фазы
+// Отсчеты с NaN/Inf и амплитудой не выше порога шумоподавления не обновляют
+// отфильтрованную ошибку: возвращается предыдущая оценка. В режиме слежения
+// фаза NCO продвигается на каждом отсчете, в том числе подавленном.
 func (cpd *CoherentPhaseDetector) Detect(inputSignal complex128) float64 {
-	// Нормируем входной сигнал
+	referencePhase := cmplx.Phase(cpd.referenceSignal)
+	if cpd.tracking {
+		referencePhase += cpd.ncoPhase
+		cpd.ncoPhase = normalizePhase(cpd.ncoPhase + cpd.ncoStep)
+	}
+
 	inputMagnitude := cmplx.Abs(inputSignal)
-	inputNorm := inputSignal / complex(inputMagnitude, 0)
+	if cmplx.IsNaN(inputSignal) || math.IsInf(inputMagnitude, 0) || inputMagnitude <= cpd.squelch {
+		cpd.squelched = true
+		return normalizePhase(cpd.filteredError - cpd.phaseOffset)
+	}
+	cpd.squelched = false
 
-	// Вычисляем разность фаз
-	phaseDiff := cmplx.Phase(inputNorm) - cmplx.Phase(cpd.referenceSignal)
+	// Вычисляем разность фаз (нормировка амплитуды на фазу не влияет)
+	phaseDiff := cmplx.Phase(inputSignal) - referencePhase
 
 	// Нормализуем разность фаз в диапазон [-π, π]
 	phaseDiff = normalizePhase(phaseDiff)
@@ -103,7 +128,91 @@ func (cpd *CoherentPhaseDetector) GetPhaseOffset() float64 {
 }
 
 // UpdateReferenceSignal обновляет опорный сигнал
+// Нулевой опорный сигнал или сигнал с NaN/Inf игнорируется, предыдущая опора сохраняется.
 func (cpd *CoherentPhaseDetector) UpdateReferenceSignal(newRef complex128) {
-	magnitude := cmplx.Abs(newRef)
-	cpd.referenceSignal = newRef / complex(magnitude, 0)
+	if refNorm, ok := normalizeReference(newRef); ok {
+		cpd.referenceSignal = refNorm
+	}
+}
+
+// normalizeReference нормирует опорный сигнал к единичной амплитуде
+// Возвращает false для нулевого сигнала и сигнала с NaN/Inf.
+func normalizeReference(ref complex128) (complex128, bool) {
+	magnitude := cmplx.Abs(ref)
+	if cmplx.IsNaN(ref) || math.IsInf(magnitude, 0) || magnitude == 0 {
+		return 0, false
+	}
+	return ref / complex(magnitude, 0), true
+}
+
+// SetSquelch задает порог амплитуды входного сигнала
+// Отсчеты с амплитудой не выше порога не обновляют оценку фазы. По умолчанию порог
+// равен 0, то есть подавляются только нулевые отсчеты.
+func (cpd *CoherentPhaseDetector) SetSquelch(threshold float64) error {
+	if threshold < 0 || math.IsNaN(threshold) || math.IsInf(threshold, 0) {
+		return fmt.Errorf("squelch threshold must be non-negative and finite, got %f", threshold)
+	}
+	cpd.squelch = threshold
+	return nil
+}
+
+// GetSquelch возвращает порог шумоподавления
+func (cpd *CoherentPhaseDetector) GetSquelch() float64 {
+	return cpd.squelch
+}
+
+// IsSquelched возвращает true, если последний отсчет был подавлен
+func (cpd *CoherentPhaseDetector) IsSquelched() bool {
+	return cpd.squelched
+}
+
+// EnableTracking включает режим слежения: опорный сигнал вращается с частотой
+// frequency (Гц, допускаются отрицательные значения) при частоте дискретизации sampleRate.
+// Фаза NCO сбрасывается в 0, начальная фаза опоры задается опорным сигналом.
+func (cpd *CoherentPhaseDetector) EnableTracking(frequency, sampleRate float64) error {
+	if sampleRate <= 0 || math.IsNaN(sampleRate) || math.IsInf(sampleRate, 0) {
+		return fmt.Errorf("sample rate must be positive and finite, got %f", sampleRate)
+	}
+	cpd.sampleRate = sampleRate
+	if err := cpd.SetTrackingFrequency(frequency); err != nil {
+		return err
+	}
+	cpd.tracking = true
+	cpd.ncoPhase = 0
+	return nil
+}
+
+// SetTrackingFrequency изменяет частоту NCO без сброса его фазы
+// Требует предварительного вызова EnableTracking.
+func (cpd *CoherentPhaseDetector) SetTrackingFrequency(frequency float64) error {
+	if cpd.sampleRate <= 0 {
+		return fmt.Errorf("tracking is not enabled")
+	}
+	if math.IsNaN(frequency) || math.Abs(frequency) >= cpd.sampleRate/2 {
+		return fmt.Errorf("frequency must be in (-%f, %f), got %f", cpd.sampleRate/2, cpd.sampleRate/2, frequency)
+	}
+	cpd.ncoFrequency = frequency
+	cpd.ncoStep = 2 * math.Pi * frequency / cpd.sampleRate
+	return nil
+}
+
+// DisableTracking выключает режим слежения, опорный сигнал снова неподвижен
+func (cpd *CoherentPhaseDetector) DisableTracking() {
+	cpd.tracking = false
+	cpd.ncoPhase = 0
+}
+
+// IsTracking возвращает true, если включен режим слежения
+func (cpd *CoherentPhaseDetector) IsTracking() bool {
+	return cpd.tracking
+}
+
+// GetTrackingFrequency возвращает частоту NCO (Гц)
+func (cpd *CoherentPhaseDetector) GetTrackingFrequency() float64 {
+	return cpd.ncoFrequency
+}
+
+// GetNCOPhase возвращает текущую фазу NCO (рад), которая будет использована для следующего отсчета
+func (cpd *CoherentPhaseDetector) GetNCOPhase() float64 {
+	return cpd.ncoPhase
 }
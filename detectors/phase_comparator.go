@@ -0,0 +1,127 @@
+package detectors
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+// PhaseComparator измеряет разность фаз двух комплексных потоков (канал B относительно канала A)
+// Взаимное произведение B·conj(A) усредняется экспоненциальным фильтром без нормировки,
+// поэтому отсчеты с большей амплитудой (и большим SNR) вносят больший вклад в оценку.
+// Разность фаз разворачивается между отсчетами, что позволяет отслеживать набег
+// фазы больше 2π (например, при измерении задержки в кабеле).
+type PhaseComparator struct {
+	alpha     float64    // Коэффициент усреднения (0 < alpha <= 1)
+	cross     complex128 // Усредненное взаимное произведение B·conj(A)
+	power     float64    // Усредненное произведение амплитуд |A|·|B|
+	wrapped   float64    // Последняя разность фаз в диапазоне (-π, π]
+	unwrapped float64    // Развернутая разность фаз
+	hasPhase  bool       // Была ли получена хотя бы одна оценка
+}
+
+// NewPhaseComparator создает фазовый компаратор с коэффициентом усреднения alpha
+// Эквивалентная постоянная времени усреднения - около 1/alpha отсчетов.
+func NewPhaseComparator(alpha float64) (*PhaseComparator, error) {
+	if alpha <= 0 || alpha > 1 || math.IsNaN(alpha) {
+		return nil, fmt.Errorf("alpha must be in (0, 1], got %f", alpha)
+	}
+	return &PhaseComparator{alpha: alpha}, nil
+}
+
+// Compare обрабатывает пару отсчетов и возвращает развернутую разность фаз (рад)
+// Отсчеты, содержащие NaN или Inf, пропускаются; нулевые отсчеты не вносят вклада в оценку.
+func (pc *PhaseComparator) Compare(a, b complex128) float64 {
+	if cmplx.IsNaN(a) || cmplx.IsNaN(b) || cmplx.IsInf(a) || cmplx.IsInf(b) {
+		return pc.unwrapped
+	}
+
+	pc.cross = complex(pc.alpha, 0)*b*cmplx.Conj(a) + complex(1-pc.alpha, 0)*pc.cross
+	pc.power = pc.alpha*cmplx.Abs(a)*cmplx.Abs(b) + (1-pc.alpha)*pc.power
+	if pc.cross == 0 {
+		return pc.unwrapped
+	}
+
+	phase := cmplx.Phase(pc.cross)
+	if pc.hasPhase {
+		pc.unwrapped += normalizePhase(phase - pc.wrapped)
+	} else {
+		pc.unwrapped = phase
+		pc.hasPhase = true
+	}
+	pc.wrapped = phase
+	return pc.unwrapped
+}
+
+// ProcessBlock обрабатывает блоки отсчетов двух каналов
+// Возвращает развернутую разность фаз для каждого отсчета.
+func (pc *PhaseComparator) ProcessBlock(a, b []complex128) ([]float64, error) {
+	if len(a) != len(b) {
+		return nil, fmt.Errorf("channel lengths differ: %d and %d", len(a), len(b))
+	}
+	result := make([]float64, len(a))
+	for i := range a {
+		result[i] = pc.Compare(a[i], b[i])
+	}
+	return result, nil
+}
+
+// Reset сбрасывает состояние компаратора
+func (pc *PhaseComparator) Reset() {
+	pc.cross = 0
+	pc.power = 0
+	pc.wrapped = 0
+	pc.unwrapped = 0
+	pc.hasPhase = false
+}
+
+// GetPhaseDifference возвращает текущую разность фаз в диапазоне (-π, π]
+func (pc *PhaseComparator) GetPhaseDifference() float64 {
+	return pc.wrapped
+}
+
+// GetUnwrappedPhase возвращает текущую развернутую разность фаз
+func (pc *PhaseComparator) GetUnwrappedPhase() float64 {
+	return pc.unwrapped
+}
+
+// GetCoherence возвращает степень согласованности фаз каналов в [0, 1]
+// Отношение модуля усредненного взаимного произведения к усредненному произведению амплитуд:
+// 1 - постоянная разность фаз, около 0 - некоррелированные каналы или шум.
+func (pc *PhaseComparator) GetCoherence() float64 {
+	if pc.power == 0 {
+		return 0
+	}
+	return math.Min(cmplx.Abs(pc.cross)/pc.power, 1)
+}
+
+// SetUnwrappedPhase задает текущую развернутую фазу (например, после калибровки
+// неоднозначности 2π по измерению на другой частоте)
+func (pc *PhaseComparator) SetUnwrappedPhase(phase float64) {
+	pc.unwrapped = phase
+	pc.wrapped = normalizePhase(phase)
+}
+
+// TimeDelay пересчитывает развернутую разность фаз в задержку канала B относительно канала A (с)
+// для несущей частоты frequency (Гц): τ = -Δφ / (2πf) (запаздывающий канал отстает по фазе).
+func (pc *PhaseComparator) TimeDelay(frequency float64) (float64, error) {
+	if frequency <= 0 {
+		return 0, fmt.Errorf("frequency must be positive, got %f", frequency)
+	}
+	return -pc.unwrapped / (2 * math.Pi * frequency), nil
+}
+
+// ArrivalAngle вычисляет угол прихода плоской волны (рад от нормали к базе)
+// для двухэлементного фазового пеленгатора: sin θ = Δφ·λ / (2π·d).
+// Канал B должен быть смещен относительно канала A на baseline в направлении положительных углов.
+// При baseline > λ/2 результат неоднозначен, используется разность фаз в (-π, π].
+func (pc *PhaseComparator) ArrivalAngle(baseline, wavelength float64) (float64, error) {
+	if baseline <= 0 || wavelength <= 0 {
+		return 0, fmt.Errorf("baseline and wavelength must be positive, got %f and %f", baseline, wavelength)
+	}
+	s := pc.wrapped * wavelength / (2 * math.Pi * baseline)
+	if math.Abs(s) > 1 {
+		return 0, fmt.Errorf("phase difference %f rad is not consistent with baseline %f and wavelength %f", pc.wrapped, baseline, wavelength)
+	}
+	return math.Asin(s), nil
+}
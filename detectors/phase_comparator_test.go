@@ -0,0 +1,156 @@
+package detectors
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+// twoChannel формирует два канала одной несущей с разностью фаз phaseDiff(n)
+// (канал B относительно A), амплитудой amplitude(n) и комплексным шумом СКО sigma
+func twoChannel(n int, freq, fs, sigma float64, phaseDiff, amplitude func(int) float64, seed int64) ([]complex128, []complex128) {
+	rng := rand.New(rand.NewSource(seed))
+	a := make([]complex128, n)
+	b := make([]complex128, n)
+	for i := range a {
+		carrier := 2 * math.Pi * freq * float64(i) / fs
+		amp := amplitude(i)
+		a[i] = cmplx.Rect(amp, carrier) + complex(sigma*rng.NormFloat64(), sigma*rng.NormFloat64())
+		b[i] = cmplx.Rect(amp, carrier+phaseDiff(i)) + complex(sigma*rng.NormFloat64(), sigma*rng.NormFloat64())
+	}
+	return a, b
+}
+
+func TestPhaseComparatorConstant(t *testing.T) {
+	want := 2.5
+	a, b := twoChannel(2000, 100, 8000, 0.1,
+		func(int) float64 { return want },
+		func(int) float64 { return 1 }, 1)
+
+	pc, err := NewPhaseComparator(0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := pc.ProcessBlock(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out[len(out)-1]; math.Abs(got-want) > 0.02 {
+		t.Errorf("phase difference = %v, want %v", got, want)
+	}
+	if c := pc.GetCoherence(); c < 0.95 {
+		t.Errorf("coherence = %v, want close to 1", c)
+	}
+}
+
+func TestPhaseComparatorUnwrap(t *testing.T) {
+	// Разность фаз нарастает на 3 оборота (изменение задержки в кабеле)
+	n := 6000
+	total := 6 * math.Pi
+	a, b := twoChannel(n, 50, 8000, 0.05,
+		func(i int) float64 { return total * float64(i) / float64(n-1) },
+		func(int) float64 { return 1 }, 2)
+
+	pc, _ := NewPhaseComparator(0.2)
+	out, _ := pc.ProcessBlock(a, b)
+	if got := out[n-1]; math.Abs(got-total) > 0.1 {
+		t.Errorf("unwrapped phase = %v, want %v", got, total)
+	}
+	if w := pc.GetPhaseDifference(); math.Abs(WrapPhase(w-total)) > 0.1 {
+		t.Errorf("wrapped phase = %v, want %v", w, WrapPhase(total))
+	}
+
+	// Задержка: канал B опережает на 6π на частоте 1 МГц -> τ = -3 мкс
+	if tau, err := pc.TimeDelay(1e6); err != nil || math.Abs(tau+3e-6) > 0.05e-6 {
+		t.Errorf("TimeDelay() = %v, %v, want -3e-6", tau, err)
+	}
+}
+
+func TestPhaseComparatorSNRWeighting(t *testing.T) {
+	// Половина отсчетов приходится на глубокое замирание, где преобладает шум
+	want := -1.2
+	fade := func(i int) float64 {
+		if (i/100)%2 == 1 {
+			return 0.01
+		}
+		return 1
+	}
+	a, b := twoChannel(4000, 300, 8000, 0.3, func(int) float64 { return want }, fade, 3)
+
+	pc, _ := NewPhaseComparator(0.005)
+	pc.ProcessBlock(a, b)
+	weighted := pc.GetPhaseDifference()
+
+	// Для сравнения - усреднение нормированных произведений (без весов)
+	var sum complex128
+	for i := range a {
+		p := b[i] * cmplx.Conj(a[i])
+		sum += p / complex(cmplx.Abs(p), 0)
+	}
+	unweighted := cmplx.Phase(sum)
+
+	if math.Abs(weighted-want) > 0.05 {
+		t.Errorf("weighted estimate = %v, want %v", weighted, want)
+	}
+	if math.Abs(weighted-want) >= math.Abs(unweighted-want) {
+		t.Errorf("weighted error %v is not below unweighted error %v",
+			math.Abs(weighted-want), math.Abs(unweighted-want))
+	}
+}
+
+func TestPhaseComparatorInvalidSamples(t *testing.T) {
+	pc, _ := NewPhaseComparator(0.1)
+	for i := 0; i < 100; i++ {
+		pc.Compare(1, cmplx.Rect(1, 0.5))
+	}
+	// Нулевые и NaN-отсчеты не должны портить оценку
+	pc.Compare(0, 0)
+	pc.Compare(complex(math.NaN(), 0), 1)
+	pc.Compare(1, cmplx.Inf())
+	if got := pc.Compare(1, cmplx.Rect(1, 0.5)); math.Abs(got-0.5) > 1e-9 || math.IsNaN(got) {
+		t.Errorf("phase after invalid samples = %v, want 0.5", got)
+	}
+
+	pc.Reset()
+	if pc.GetUnwrappedPhase() != 0 || pc.GetCoherence() != 0 {
+		t.Error("Reset() did not clear state")
+	}
+	if got := pc.Compare(0, 0); got != 0 {
+		t.Errorf("zero input after reset = %v, want 0", got)
+	}
+}
+
+func TestPhaseComparatorArrivalAngle(t *testing.T) {
+	wavelength := 0.3
+	baseline := 0.15
+	theta := 0.4
+	pc, _ := NewPhaseComparator(1)
+	pc.Compare(1, cmplx.Rect(1, 2*math.Pi*baseline*math.Sin(theta)/wavelength))
+
+	got, err := pc.ArrivalAngle(baseline, wavelength)
+	if err != nil || math.Abs(got-theta) > 1e-9 {
+		t.Errorf("ArrivalAngle() = %v, %v, want %v", got, err, theta)
+	}
+	if _, err := pc.ArrivalAngle(0.01, wavelength); err == nil {
+		t.Error("expected error for inconsistent phase difference")
+	}
+	if _, err := pc.ArrivalAngle(0, wavelength); err == nil {
+		t.Error("expected error for zero baseline")
+	}
+}
+
+func TestNewPhaseComparatorErrors(t *testing.T) {
+	for _, alpha := range []float64{0, -0.1, 1.5, math.NaN()} {
+		if _, err := NewPhaseComparator(alpha); err == nil {
+			t.Errorf("expected error for alpha = %v", alpha)
+		}
+	}
+	pc, _ := NewPhaseComparator(0.5)
+	if _, err := pc.ProcessBlock(make([]complex128, 3), make([]complex128, 2)); err == nil {
+		t.Error("expected error for length mismatch")
+	}
+	if _, err := pc.TimeDelay(0); err == nil {
+		t.Error("expected error for zero frequency")
+	}
+}
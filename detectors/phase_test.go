@@ -430,3 +430,135 @@ func TestCoherentPhaseDetector_FilteringEffect(t *testing.T) {
 		})
 	}
 }
+
+func TestCoherentPhaseDetector_ZeroAndNaNInput(t *testing.T) {
+	cpd := NewCoherentPhaseDetector(complex(1, 0), 0.5)
+	cpd.Detect(complex(0, 1)) // фаза π/2
+	want := cpd.GetFilteredError()
+
+	invalid := []complex128{
+		complex(0, 0),
+		complex(math.NaN(), 0),
+		complex(0, math.Inf(-1)),
+		cmplx.NaN(),
+	}
+	for _, input := range invalid {
+		got := cpd.Detect(input)
+		if math.IsNaN(got) || !cpd.IsSquelched() {
+			t.Errorf("Detect(%v) = %v, squelched = %v", input, got, cpd.IsSquelched())
+		}
+		if cpd.GetFilteredError() != want {
+			t.Errorf("Detect(%v) changed filtered error to %v, want %v", input, cpd.GetFilteredError(), want)
+		}
+	}
+
+	// После недопустимых отсчетов детектор продолжает работать
+	got := cpd.Detect(complex(0, 1))
+	if math.IsNaN(got) || cpd.IsSquelched() {
+		t.Fatalf("Detect() after invalid input = %v", got)
+	}
+	if math.Abs(cpd.GetFilteredError()-(0.5*math.Pi/2+0.5*want)) > 1e-12 {
+		t.Errorf("filtered error = %v, want %v", cpd.GetFilteredError(), 0.5*math.Pi/2+0.5*want)
+	}
+}
+
+func TestCoherentPhaseDetector_InvalidReference(t *testing.T) {
+	for _, ref := range []complex128{0, cmplx.NaN(), cmplx.Inf()} {
+		cpd := NewCoherentPhaseDetector(ref, math.NaN())
+		if cpd.alpha != 0.1 {
+			t.Errorf("alpha = %v, want default 0.1", cpd.alpha)
+		}
+		if cpd.referenceSignal != 1 {
+			t.Errorf("reference for %v = %v, want 1", ref, cpd.referenceSignal)
+		}
+		if got := cpd.Detect(complex(0, 1)); math.IsNaN(got) {
+			t.Errorf("Detect() with reference %v returned NaN", ref)
+		}
+	}
+
+	cpd := NewCoherentPhaseDetector(complex(0, 1), 0.5)
+	cpd.UpdateReferenceSignal(0)
+	cpd.UpdateReferenceSignal(cmplx.NaN())
+	if cmplx.Abs(cpd.referenceSignal-complex(0, 1)) > 1e-12 {
+		t.Errorf("invalid reference update changed reference to %v", cpd.referenceSignal)
+	}
+}
+
+func TestCoherentPhaseDetector_Squelch(t *testing.T) {
+	cpd := NewCoherentPhaseDetector(complex(1, 0), 1)
+	if err := cpd.SetSquelch(-1); err == nil {
+		t.Error("expected error for negative squelch")
+	}
+	if err := cpd.SetSquelch(math.NaN()); err == nil {
+		t.Error("expected error for NaN squelch")
+	}
+	if err := cpd.SetSquelch(0.1); err != nil {
+		t.Fatal(err)
+	}
+	if cpd.GetSquelch() != 0.1 {
+		t.Errorf("GetSquelch() = %v, want 0.1", cpd.GetSquelch())
+	}
+
+	cpd.Detect(cmplx.Rect(1, 0.3))
+	// Слабый отсчет с другой фазой игнорируется
+	if got := cpd.Detect(cmplx.Rect(0.05, -2)); math.Abs(got-0.3) > 1e-12 || !cpd.IsSquelched() {
+		t.Errorf("weak sample: Detect() = %v, squelched = %v", got, cpd.IsSquelched())
+	}
+	if got := cpd.Detect(cmplx.Rect(0.5, -2)); math.Abs(got+2) > 1e-12 || cpd.IsSquelched() {
+		t.Errorf("strong sample: Detect() = %v, squelched = %v", got, cpd.IsSquelched())
+	}
+}
+
+func TestCoherentPhaseDetector_Tracking(t *testing.T) {
+	fs := 8000.0
+	carrier := 1000.0
+	offset := 0.7
+
+	cpd := NewCoherentPhaseDetector(cmplx.Rect(1, 0.2), 0.2)
+	if err := cpd.SetTrackingFrequency(carrier); err == nil {
+		t.Error("expected error before tracking is enabled")
+	}
+	if err := cpd.EnableTracking(carrier, 0); err == nil {
+		t.Error("expected error for zero sample rate")
+	}
+	if err := cpd.EnableTracking(fs, fs); err == nil {
+		t.Error("expected error for frequency above Nyquist")
+	}
+	if err := cpd.EnableTracking(carrier, fs); err != nil {
+		t.Fatal(err)
+	}
+	if !cpd.IsTracking() || cpd.GetTrackingFrequency() != carrier {
+		t.Fatalf("tracking = %v, frequency = %v", cpd.IsTracking(), cpd.GetTrackingFrequency())
+	}
+
+	// Несущая вращается, но разность фаз с опорой постоянна (опора 0.2 рад + NCO)
+	var got float64
+	for n := 0; n < 200; n++ {
+		input := cmplx.Rect(1, 2*math.Pi*carrier*float64(n)/fs+0.2+offset)
+		if n == 100 {
+			input = 0 // Пропуск отсчета не нарушает синхронность NCO
+		}
+		got = cpd.Detect(input)
+	}
+	if math.Abs(got-offset) > 1e-6 {
+		t.Errorf("tracking phase = %v, want %v", got, offset)
+	}
+	wantNCO := normalizePhase(2 * math.Pi * carrier * 200 / fs)
+	if math.Abs(normalizePhase(cpd.GetNCOPhase()-wantNCO)) > 1e-9 {
+		t.Errorf("NCO phase = %v, want %v", cpd.GetNCOPhase(), wantNCO)
+	}
+
+	// Смена частоты сохраняет фазу NCO
+	before := cpd.GetNCOPhase()
+	if err := cpd.SetTrackingFrequency(-500); err != nil {
+		t.Fatal(err)
+	}
+	if cpd.GetNCOPhase() != before {
+		t.Error("SetTrackingFrequency() reset NCO phase")
+	}
+
+	cpd.DisableTracking()
+	if cpd.IsTracking() || cpd.GetNCOPhase() != 0 {
+		t.Error("DisableTracking() did not stop NCO")
+	}
+}
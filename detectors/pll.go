@@ -0,0 +1,340 @@
+package detectors
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+// PLLConfig - параметры частотного детектора на основе PLL
+type PLLConfig struct {
+	SampleRate float64 // Частота дискретизации (Гц)
+	Bandwidth  float64 // Собственная частота петли (Гц)
+	Damping    float64 // Коэффициент затухания (обычно 0.5-1.0)
+	Order      int     // Порядок петли: 2 (нулевая ошибка при скачке частоты) или 3 (при линейном изменении частоты)
+
+	// Детектор захвата: метрика m = <cos e> усредняется с постоянной времени LockTime;
+	// захват объявляется при m >= LockThreshold, срыв - при m < UnlockThreshold (гистерезис)
+	LockTime        float64 // Постоянная времени усреднения (с)
+	LockThreshold   float64 // Порог захвата (0..1)
+	UnlockThreshold float64 // Порог срыва (0..LockThreshold)
+}
+
+// DefaultPLLConfig возвращает параметры по умолчанию: петля 2-го порядка, затухание 0.707,
+// постоянная времени детектора захвата 10/bandwidth, пороги 0.9 и 0.7
+func DefaultPLLConfig(sampleRate, bandwidth float64) PLLConfig {
+	config := PLLConfig{
+		SampleRate:      sampleRate,
+		Bandwidth:       bandwidth,
+		Damping:         0.707,
+		Order:           2,
+		LockThreshold:   0.9,
+		UnlockThreshold: 0.7,
+	}
+	if bandwidth > 0 {
+		config.LockTime = 10 / bandwidth
+	}
+	return config
+}
+
+// Validate проверяет параметры PLL
+func (c PLLConfig) Validate() error {
+	if c.SampleRate <= 0 {
+		return fmt.Errorf("sample rate must be positive, got %f", c.SampleRate)
+	}
+	if c.Bandwidth <= 0 || c.Bandwidth >= c.SampleRate/2 {
+		return fmt.Errorf("bandwidth must be in (0, %f), got %f", c.SampleRate/2, c.Bandwidth)
+	}
+	if c.Damping <= 0 {
+		return fmt.Errorf("damping must be positive, got %f", c.Damping)
+	}
+	if c.Order != 2 && c.Order != 3 {
+		return fmt.Errorf("loop order must be 2 or 3, got %d", c.Order)
+	}
+	if c.LockTime <= 0 {
+		return fmt.Errorf("lock time must be positive, got %f", c.LockTime)
+	}
+	if c.LockThreshold <= 0 || c.LockThreshold > 1 {
+		return fmt.Errorf("lock threshold must be in (0, 1], got %f", c.LockThreshold)
+	}
+	if c.UnlockThreshold < 0 || c.UnlockThreshold > c.LockThreshold {
+		return fmt.Errorf("unlock threshold must be in [0, %f], got %f", c.LockThreshold, c.UnlockThreshold)
+	}
+	return nil
+}
+
+// pllMaxNormalizedFrequency - ограничение частоты петли (рад/отсчет), около fs/12.6
+const pllMaxNormalizedFrequency = 0.5
+
+// pllMaxSimulationSamples ограничивает длину моделирования при оценке полос захвата и удержания
+const pllMaxSimulationSamples = 10_000_000
+
+// NewPLLFrequencyDetectorWithConfig создает частотный детектор на основе PLL с заданными параметрами
+func NewPLLFrequencyDetectorWithConfig(config PLLConfig) (*PLLFrequencyDetector, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return newPLLFrequencyDetector(config), nil
+}
+
+// newPLLFrequencyDetector создает детектор без проверки параметров
+func newPLLFrequencyDetector(config PLLConfig) *PLLFrequencyDetector {
+	pll := &PLLFrequencyDetector{
+		sampleRate: config.SampleRate,
+		bandwidth:  config.Bandwidth,
+		damping:    config.Damping,
+		order:      config.Order,
+		lock: pllLockDetector{
+			lockTime:        config.LockTime,
+			lambda:          1 - math.Exp(-1/(config.LockTime*config.SampleRate)),
+			lockThreshold:   config.LockThreshold,
+			unlockThreshold: config.UnlockThreshold,
+			fold:            1,
+		},
+	}
+	pll.updateLoopCoefficients()
+	return pll
+}
+
+// loopFilterGains рассчитывает коэффициенты пропорционально-интегрирующего фильтра петли
+// bandwidth - собственная частота петли (Гц), damping - коэффициент затухания.
+// Для 2-го порядка используется дискретизация билинейным преобразованием;
+// для 3-го порядка характеристический полином петли равен (s + ωn)(s² + 2ζωn·s + ωn²),
+// что дает α = (1 + 2ζ)ωn, β = (1 + 2ζ)ωn², γ = ωn³ (при ωn << 1).
+func loopFilterGains(bandwidth, sampleRate, damping float64, order int) (alpha, beta, gamma float64) {
+	naturalFreq := 2 * math.Pi * bandwidth / sampleRate // Нормализованная собственная частота
+
+	if order == 3 {
+		k := 1 + 2*damping
+		return k * naturalFreq, k * naturalFreq * naturalFreq, naturalFreq * naturalFreq * naturalFreq
+	}
+
+	// Дискретные коэффициенты для петли 2-го порядка
+	den := 4 + 4*damping*naturalFreq + math.Pow(naturalFreq, 2)
+	alpha = (4 * damping * naturalFreq) / den
+	beta = (4 * math.Pow(naturalFreq, 2)) / den
+	return alpha, beta, 0
+}
+
+// updateLoopCoefficients пересчитывает коэффициенты петли
+func (pll *PLLFrequencyDetector) updateLoopCoefficients() {
+	pll.alpha, pll.beta, pll.gamma = loopFilterGains(pll.bandwidth, pll.sampleRate, pll.damping, pll.order)
+}
+
+// SetDamping устанавливает коэффициент затухания петли
+func (pll *PLLFrequencyDetector) SetDamping(damping float64) error {
+	if damping <= 0 {
+		return fmt.Errorf("damping must be positive, got %f", damping)
+	}
+	pll.damping = damping
+	pll.updateLoopCoefficients()
+	return nil
+}
+
+// GetDamping возвращает коэффициент затухания петли
+func (pll *PLLFrequencyDetector) GetDamping() float64 {
+	return pll.damping
+}
+
+// GetOrder возвращает порядок петли
+func (pll *PLLFrequencyDetector) GetOrder() int {
+	return pll.order
+}
+
+// PLLLockEventType - тип события детектора захвата
+type PLLLockEventType int
+
+const (
+	PLLLocked   PLLLockEventType = iota // Захват
+	PLLUnlocked                         // Срыв слежения
+)
+
+// String возвращает название события
+func (t PLLLockEventType) String() string {
+	switch t {
+	case PLLLocked:
+		return "Locked"
+	case PLLUnlocked:
+		return "Unlocked"
+	default:
+		return "Unknown"
+	}
+}
+
+// PLLLockEvent - событие захвата или срыва слежения
+type PLLLockEvent struct {
+	Type      PLLLockEventType
+	Sample    int     // Номер отсчета с момента сброса
+	Frequency float64 // Оценка частоты в момент события (Гц)
+}
+
+// pllLockDetector - детектор захвата с гистерезисом
+type pllLockDetector struct {
+	lockTime        float64 // Постоянная времени усреднения (с)
+	lambda          float64 // Коэффициент экспоненциального усреднения
+	lockThreshold   float64
+	unlockThreshold float64
+	fold            float64 // Множитель ошибки в метрике: 1 для ФАПЧ, M для петли Костаса M-PSK
+
+	metric    float64 // Усредненный cos(fold·e)
+	errMean   float64 // Усредненная ошибка фазы
+	errSquare float64 // Усредненный квадрат ошибки фазы
+	locked    bool
+	sample    int
+	events    []PLLLockEvent
+}
+
+// update обновляет статистику по очередной ошибке фазы
+// Ошибка детектора M-PSK свернута в (-π/M, π/M], поэтому для нее усредняется cos(M·e):
+// иначе на чистом шуме <cos e> близок к 1 (около 0.90 для QPSK) и захват ложно фиксируется.
+func (ld *pllLockDetector) update(phaseError, frequency float64) {
+	ld.metric += ld.lambda * (math.Cos(ld.fold*phaseError) - ld.metric)
+	ld.errMean += ld.lambda * (phaseError - ld.errMean)
+	ld.errSquare += ld.lambda * (phaseError*phaseError - ld.errSquare)
+
+	if !ld.locked && ld.metric >= ld.lockThreshold {
+		ld.locked = true
+		ld.events = append(ld.events, PLLLockEvent{Type: PLLLocked, Sample: ld.sample, Frequency: frequency})
+	} else if ld.locked && ld.metric < ld.unlockThreshold {
+		ld.locked = false
+		ld.events = append(ld.events, PLLLockEvent{Type: PLLUnlocked, Sample: ld.sample, Frequency: frequency})
+	}
+	ld.sample++
+}
+
+// reset сбрасывает состояние детектора захвата
+func (ld *pllLockDetector) reset() {
+	ld.metric = 0
+	ld.errMean = 0
+	ld.errSquare = 0
+	ld.locked = false
+	ld.sample = 0
+	ld.events = nil
+}
+
+// IsLocked возвращает true, если петля находится в состоянии захвата
+func (pll *PLLFrequencyDetector) IsLocked() bool {
+	return pll.lock.locked
+}
+
+// GetLockMetric возвращает усредненное значение cos(e) (1 - идеальный захват, около 0 - нет захвата)
+func (pll *PLLFrequencyDetector) GetLockMetric() float64 {
+	return pll.lock.metric
+}
+
+// GetPhaseErrorVariance возвращает усредненную дисперсию ошибки фазы (рад²)
+func (pll *PLLFrequencyDetector) GetPhaseErrorVariance() float64 {
+	return math.Max(0, pll.lock.errSquare-pll.lock.errMean*pll.lock.errMean)
+}
+
+// TakeLockEvents возвращает накопленные события захвата и срыва и очищает очередь
+func (pll *PLLFrequencyDetector) TakeLockEvents() []PLLLockEvent {
+	events := pll.lock.events
+	pll.lock.events = nil
+	return events
+}
+
+// config восстанавливает конфигурацию детектора
+func (pll *PLLFrequencyDetector) config() PLLConfig {
+	return PLLConfig{
+		SampleRate:      pll.sampleRate,
+		Bandwidth:       pll.bandwidth,
+		Damping:         pll.damping,
+		Order:           pll.order,
+		LockTime:        pll.lock.lockTime,
+		LockThreshold:   pll.lock.lockThreshold,
+		UnlockThreshold: pll.lock.unlockThreshold,
+	}
+}
+
+// pllFrequencyLimit возвращает наибольшую частоту (Гц), которую может отслеживать петля
+func (pll *PLLFrequencyDetector) pllFrequencyLimit() float64 {
+	return pllMaxNormalizedFrequency * pll.sampleRate / (2 * math.Pi)
+}
+
+// EstimatePullInRange оценивает полосу захвата (Гц) моделированием
+// Копия петли из начального состояния (частота 0) возбуждается единичным комплексным
+// тоном с расстройкой Δf в течение duration секунд; захват считается успешным, если
+// к концу интервала детектор сообщает о захвате и частота совпадает с Δf с точностью
+// до 0.1 полосы петли. Граница находится бисекцией в диапазоне [0, 0.5·fs/(2π)]:
+// выше ограничения частоты петли захват невозможен.
+// Состояние самого детектора не меняется.
+func (pll *PLLFrequencyDetector) EstimatePullInRange(duration float64) (float64, error) {
+	if duration <= 0 {
+		return 0, fmt.Errorf("duration must be positive, got %f", duration)
+	}
+	n := int(duration * pll.sampleRate)
+	if n > pllMaxSimulationSamples {
+		return 0, fmt.Errorf("duration %f s requires %d samples per trial, limit is %d", duration, n, pllMaxSimulationSamples)
+	}
+
+	acquires := func(offset float64) bool {
+		probe := newPLLFrequencyDetector(pll.config())
+		w := 2 * math.Pi * offset / pll.sampleRate
+		for i := 0; i < n; i++ {
+			probe.DetectFrequency(cmplx.Exp(complex(0, w*float64(i))))
+		}
+		return probe.IsLocked() && math.Abs(probe.GetCurrentFrequency()-offset) < pll.bandwidth/10
+	}
+
+	if !acquires(0) {
+		return 0, nil
+	}
+	lo, hi := 0.0, pll.pllFrequencyLimit()
+	if acquires(hi) {
+		return hi, nil
+	}
+	for hi-lo > pll.bandwidth/100 {
+		mid := (lo + hi) / 2
+		if acquires(mid) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo, nil
+}
+
+// EstimateHoldRange оценивает полосу удержания (Гц) моделированием
+// Копия петли захватывает тон нулевой частоты, после чего частота тона медленно
+// увеличивается (на sweepRate Гц/с) до срыва слежения. Возвращается последняя частота
+// тона, которую петля отслеживала с точностью до 0.1 полосы. Развертка ограничена
+// частотой 0.5·fs/(2π), выше которой петля не может следить; если срыва не произошло,
+// возвращается последняя отслеженная частота вблизи этой границы.
+// Состояние детектора не меняется.
+func (pll *PLLFrequencyDetector) EstimateHoldRange(sweepRate float64) (float64, error) {
+	if sweepRate <= 0 {
+		return 0, fmt.Errorf("sweep rate must be positive, got %f", sweepRate)
+	}
+	limit := pll.pllFrequencyLimit()
+	step := sweepRate / pll.sampleRate
+	if steps := limit / step; steps > pllMaxSimulationSamples {
+		return 0, fmt.Errorf("sweep rate %f Hz/s requires %.0f samples, limit is %d", sweepRate, steps, pllMaxSimulationSamples)
+	}
+
+	probe := newPLLFrequencyDetector(pll.config())
+	settle := int(pll.lock.lockTime * pll.sampleRate * 10)
+	var phase float64
+	for i := 0; i < settle; i++ {
+		probe.DetectFrequency(complex(1, 0))
+	}
+	if !probe.IsLocked() {
+		return 0, nil
+	}
+	probe.TakeLockEvents()
+
+	// Детектор захвата реагирует с запаздыванием, поэтому границей считается
+	// последняя частота, на которой оценка петли совпадала с частотой тона
+	var tracked float64
+	for freq := 0.0; freq <= limit; freq += step {
+		phase += 2 * math.Pi * freq / pll.sampleRate
+		estimate := probe.DetectFrequency(cmplx.Exp(complex(0, phase)))
+		if math.Abs(estimate-freq) < pll.bandwidth/10 {
+			tracked = freq
+		}
+		if !probe.IsLocked() {
+			break
+		}
+	}
+	return tracked, nil
+}
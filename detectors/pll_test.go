@@ -0,0 +1,180 @@
+package detectors
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+// TestPLLThirdOrderRamp проверяет слежение за линейно меняющейся частотой
+func TestPLLThirdOrderRamp(t *testing.T) {
+	fs := 8000.0
+	ramp := 200.0 // Гц/с
+
+	steadyError := func(order int) float64 {
+		config := DefaultPLLConfig(fs, 50)
+		config.Order = order
+		pll, err := NewPLLFrequencyDetectorWithConfig(config)
+		if err != nil {
+			t.Fatalf("order %d: %v", order, err)
+		}
+
+		var phase, worst float64
+		n := int(2 * fs)
+		for i := 0; i < n; i++ {
+			freq := 100 + ramp*float64(i)/fs
+			phase += 2 * math.Pi * freq / fs
+			est := pll.DetectFrequency(cmplx.Exp(complex(0, phase)))
+			if i > n/2 {
+				worst = math.Max(worst, math.Abs(est-freq))
+			}
+		}
+		if !pll.IsLocked() {
+			t.Errorf("order %d: loop should stay locked", order)
+		}
+		return worst
+	}
+
+	second, third := steadyError(2), steadyError(3)
+	if third > 0.1 {
+		t.Errorf("third-order loop frequency error %f Hz on ramp, want < 0.1", third)
+	}
+	if third >= second {
+		t.Errorf("third-order error %f should be below second-order error %f", third, second)
+	}
+}
+
+// TestPLLLockDetector проверяет события захвата и срыва с гистерезисом
+func TestPLLLockDetector(t *testing.T) {
+	fs := 8000.0
+	pll, err := NewPLLFrequencyDetectorWithConfig(DefaultPLLConfig(fs, 50))
+	if err != nil {
+		t.Fatalf("NewPLLFrequencyDetectorWithConfig failed: %v", err)
+	}
+
+	// Тон 300 Гц, затем шум без тона
+	rng := rand.New(rand.NewSource(3))
+	n := int(2 * fs)
+	for i := 0; i < n; i++ {
+		pll.DetectFrequency(cmplx.Exp(complex(0, 2*math.Pi*300*float64(i)/fs)))
+	}
+	if !pll.IsLocked() {
+		t.Fatal("loop should be locked on a clean tone")
+	}
+	if v := pll.GetPhaseErrorVariance(); v > 1e-4 {
+		t.Errorf("phase error variance %g on a clean tone", v)
+	}
+	for i := 0; i < n; i++ {
+		pll.DetectFrequency(complex(rng.NormFloat64(), rng.NormFloat64()))
+	}
+	if pll.IsLocked() {
+		t.Error("loop should unlock on noise")
+	}
+
+	events := pll.TakeLockEvents()
+	if len(events) != 2 || events[0].Type != PLLLocked || events[1].Type != PLLUnlocked {
+		t.Fatalf("unexpected events %+v", events)
+	}
+	if math.Abs(events[0].Frequency-300) > 5 {
+		t.Errorf("lock frequency %f, want 300", events[0].Frequency)
+	}
+	if events[1].Sample <= n {
+		t.Errorf("unlock at sample %d, expected after %d", events[1].Sample, n)
+	}
+	if len(pll.TakeLockEvents()) != 0 {
+		t.Error("event queue should be empty")
+	}
+
+	// Умеренный шум повышает дисперсию, но не срывает слежение
+	pll.Reset()
+	for i := 0; i < n; i++ {
+		s := cmplx.Exp(complex(0, 2*math.Pi*300*float64(i)/fs))
+		pll.DetectFrequency(s + complex(0.1*rng.NormFloat64(), 0.1*rng.NormFloat64()))
+	}
+	if !pll.IsLocked() {
+		t.Error("loop should stay locked at 20 dB SNR")
+	}
+	if v := pll.GetPhaseErrorVariance(); v < 1e-4 || v > 0.05 {
+		t.Errorf("phase error variance %g at 20 dB SNR", v)
+	}
+}
+
+// TestPLLRanges проверяет оценки полос захвата и удержания
+func TestPLLRanges(t *testing.T) {
+	fs := 8000.0
+	pll, _ := NewPLLFrequencyDetectorWithConfig(DefaultPLLConfig(fs, 50))
+
+	pullIn, err := pll.EstimatePullInRange(1)
+	if err != nil {
+		t.Fatalf("EstimatePullInRange failed: %v", err)
+	}
+	hold, err := pll.EstimateHoldRange(200)
+	if err != nil {
+		t.Fatalf("EstimateHoldRange failed: %v", err)
+	}
+
+	// Ограничение частоты петли 0.5 рад/отсчет определяет верхнюю границу
+	limit := 0.5 * fs / (2 * math.Pi)
+	if pullIn < 100 || pullIn > limit+1 {
+		t.Errorf("pull-in range %f Hz, expected in [100, %f]", pullIn, limit)
+	}
+	if hold < pullIn-1 || hold > limit+5 { // Допуск слежения - 0.1 полосы петли
+		t.Errorf("hold range %f Hz, expected in [%f, %f]", hold, pullIn, limit)
+	}
+	if pll.IsLocked() || pll.GetCurrentFrequency() != 0 {
+		t.Error("estimation must not change detector state")
+	}
+
+	// Слишком медленная развертка или длинный захват требуют недопустимо долгого моделирования
+	if _, err := NewPLLFrequencyDetector(48000, 50).EstimateHoldRange(1); err == nil {
+		t.Error("expected error for sweep exceeding simulation limit")
+	}
+	if _, err := NewPLLFrequencyDetector(48000, 50).EstimatePullInRange(1000); err == nil {
+		t.Error("expected error for duration exceeding simulation limit")
+	}
+}
+
+// TestPLLLegacyConstructorWideBandwidth проверяет, что прежний конструктор принимает
+// полосу выше fs/2, тогда как конструктор с конфигурацией ее отвергает
+func TestPLLLegacyConstructorWideBandwidth(t *testing.T) {
+	pll := NewPLLFrequencyDetector(8000, 5000)
+	if pll.bandwidth != 5000 {
+		t.Errorf("expected bandwidth 5000, got %f", pll.bandwidth)
+	}
+	pll.SetBandwidth(6000)
+	if pll.bandwidth != 6000 {
+		t.Errorf("expected bandwidth 6000, got %f", pll.bandwidth)
+	}
+	if _, err := NewPLLFrequencyDetectorWithConfig(DefaultPLLConfig(8000, 5000)); err == nil {
+		t.Error("expected error for bandwidth above fs/2")
+	}
+}
+
+// TestPLLConfigValidation проверяет проверку параметров
+func TestPLLConfigValidation(t *testing.T) {
+	base := DefaultPLLConfig(8000, 50)
+	mutations := []func(*PLLConfig){
+		func(c *PLLConfig) { c.Order = 4 },
+		func(c *PLLConfig) { c.Damping = 0 },
+		func(c *PLLConfig) { c.Bandwidth = 5000 },
+		func(c *PLLConfig) { c.LockTime = 0 },
+		func(c *PLLConfig) { c.UnlockThreshold = 0.95 },
+	}
+	for i, mutate := range mutations {
+		config := base
+		mutate(&config)
+		if _, err := NewPLLFrequencyDetectorWithConfig(config); err == nil {
+			t.Errorf("case %d: expected error", i)
+		}
+	}
+
+	pll, _ := NewPLLFrequencyDetectorWithConfig(base)
+	alpha := pll.alpha
+	if err := pll.SetDamping(1.0); err != nil || pll.alpha == alpha || pll.GetDamping() != 1.0 {
+		t.Error("SetDamping should update loop coefficients")
+	}
+	if err := pll.SetDamping(-1); err == nil {
+		t.Error("expected error for negative damping")
+	}
+}
@@ -0,0 +1,201 @@
+package detectors
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+
+	"github.com/Alexxtn105/dsp/fft"
+)
+
+// Оценщики частоты одиночного комплексного тона x[n] = A·exp(j(2πf·n/fs + φ)) + w[n]
+// по блоку отсчетов. Для действительного сигнала предварительно сформируйте
+// аналитический сигнал (пакет hilbert).
+
+// FrequencyCRLB возвращает границу Крамера-Рао для дисперсии оценки частоты (Гц²)
+// snr - отношение сигнал/шум A²/σ² (σ² - суммарная дисперсия комплексного шума), в разах;
+// n - длина блока. var(f) >= 6·fs² / ((2π)²·SNR·N·(N² - 1)).
+func FrequencyCRLB(snr float64, n int, sampleRate float64) float64 {
+	if snr <= 0 || n < 2 {
+		return math.Inf(1)
+	}
+	N := float64(n)
+	return 6 * sampleRate * sampleRate / (4 * math.Pi * math.Pi * snr * N * (N*N - 1))
+}
+
+// validateToneBlock проверяет параметры блока
+func validateToneBlock(x []complex128, sampleRate float64, minLength int) error {
+	if sampleRate <= 0 {
+		return fmt.Errorf("sample rate must be positive, got %f", sampleRate)
+	}
+	if len(x) < minLength {
+		return fmt.Errorf("block must contain at least %d samples, got %d", minLength, len(x))
+	}
+	return nil
+}
+
+// EstimateFrequencyKay оценивает частоту взвешенным усреднением разностей фаз (Kay, 1989)
+// f = fs/(2π)·Σ w[t]·arg(x[t+1]·conj(x[t])), w[t] = 1.5N/(N²-1)·[1 - ((t - N/2 + 1)/(N/2))²].
+// Достигает границы Крамера-Рао при высоком SNR (выше порога около 20-25 дБ),
+// диапазон однозначности |f| < fs/2.
+func EstimateFrequencyKay(x []complex128, sampleRate float64) (float64, error) {
+	if err := validateToneBlock(x, sampleRate, 2); err != nil {
+		return 0, err
+	}
+
+	N := float64(len(x))
+	half := N / 2
+	var sum float64
+	for t := 0; t < len(x)-1; t++ {
+		u := (float64(t) - half + 1) / half
+		w := 1.5 * N / (N*N - 1) * (1 - u*u)
+		sum += w * cmplx.Phase(x[t+1]*cmplx.Conj(x[t]))
+	}
+	return sum * sampleRate / (2 * math.Pi), nil
+}
+
+// autocorrelation вычисляет оценки R(m) = 1/(N-m)·Σ x[k]·conj(x[k-m]) для m = 1..maxLag
+func autocorrelation(x []complex128, maxLag int) []complex128 {
+	r := make([]complex128, maxLag+1)
+	for m := 1; m <= maxLag; m++ {
+		var sum complex128
+		for k := m; k < len(x); k++ {
+			sum += x[k] * cmplx.Conj(x[k-m])
+		}
+		r[m] = sum / complex(float64(len(x)-m), 0)
+	}
+	return r
+}
+
+// defaultCorrelationLags возвращает число задержек по умолчанию (N/2)
+func defaultCorrelationLags(n, lags int) (int, error) {
+	if lags == 0 {
+		lags = n / 2
+	}
+	if lags < 1 || lags >= n {
+		return 0, fmt.Errorf("number of lags must be in [1, %d), got %d", n, lags)
+	}
+	return lags, nil
+}
+
+// EstimateFrequencyFitz оценивает частоту по фазам автокорреляции (Fitz, 1991)
+// f = fs·Σ_{m=1..M} arg R(m) / (π·M·(M+1)). lags - число задержек M (0 - N/2).
+// Предназначен для малых расстроек: диапазон однозначности |f| < fs/(2M).
+func EstimateFrequencyFitz(x []complex128, sampleRate float64, lags int) (float64, error) {
+	if err := validateToneBlock(x, sampleRate, 2); err != nil {
+		return 0, err
+	}
+	m, err := defaultCorrelationLags(len(x), lags)
+	if err != nil {
+		return 0, err
+	}
+
+	r := autocorrelation(x, m)
+	var sum float64
+	for k := 1; k <= m; k++ {
+		sum += cmplx.Phase(r[k])
+	}
+	M := float64(m)
+	return sampleRate * sum / (math.Pi * M * (M + 1)), nil
+}
+
+// EstimateFrequencyLuiseReggiannini оценивает частоту по фазе суммы автокорреляций
+// (Luise & Reggiannini, 1995): f = fs·arg(Σ_{m=1..M} R(m)) / (π·(M+1)).
+// lags - число задержек M (0 - N/2). Диапазон однозначности |f| < fs/(M+1).
+func EstimateFrequencyLuiseReggiannini(x []complex128, sampleRate float64, lags int) (float64, error) {
+	if err := validateToneBlock(x, sampleRate, 2); err != nil {
+		return 0, err
+	}
+	m, err := defaultCorrelationLags(len(x), lags)
+	if err != nil {
+		return 0, err
+	}
+
+	r := autocorrelation(x, m)
+	var sum complex128
+	for k := 1; k <= m; k++ {
+		sum += r[k]
+	}
+	return sampleRate * cmplx.Phase(sum) / (math.Pi * float64(m+1)), nil
+}
+
+// PeakInterpolation - метод уточнения положения пика ДПФ
+type PeakInterpolation int
+
+const (
+	InterpolationNone     PeakInterpolation = iota // Центр бина максимума
+	InterpolationJacobsen                          // Jacobsen: δ = -Re[(X₊ - X₋)/(2X₀ - X₋ - X₊)]
+	InterpolationQuinn                             // Второй оценщик Куинна (Quinn, 1997)
+	InterpolationCandan                            // Candan: поправка Jacobsen с коэффициентом tan(π/N)/(π/N)
+)
+
+// String возвращает название метода интерполяции
+func (p PeakInterpolation) String() string {
+	switch p {
+	case InterpolationNone:
+		return "None"
+	case InterpolationJacobsen:
+		return "Jacobsen"
+	case InterpolationQuinn:
+		return "Quinn"
+	case InterpolationCandan:
+		return "Candan"
+	default:
+		return "Unknown"
+	}
+}
+
+// EstimateFrequencyFFT оценивает частоту по пику ДПФ (прямоугольное окно) с интерполяцией
+// Диапазон однозначности |f| < fs/2; частоты выше fs/2 возвращаются как отрицательные.
+func EstimateFrequencyFFT(x []complex128, sampleRate float64, method PeakInterpolation) (float64, error) {
+	if err := validateToneBlock(x, sampleRate, 3); err != nil {
+		return 0, err
+	}
+
+	n := len(x)
+	spectrum := fft.FFT(x)
+
+	k := 0
+	for i := range spectrum {
+		if cmplx.Abs(spectrum[i]) > cmplx.Abs(spectrum[k]) {
+			k = i
+		}
+	}
+	xm := spectrum[(k-1+n)%n]
+	x0 := spectrum[k]
+	xp := spectrum[(k+1)%n]
+
+	var delta float64
+	switch method {
+	case InterpolationNone:
+	case InterpolationJacobsen, InterpolationCandan:
+		den := 2*x0 - xm - xp
+		if den != 0 {
+			delta = -real((xp - xm) / den)
+		}
+		if method == InterpolationCandan {
+			c := math.Pi / float64(n)
+			delta *= math.Tan(c) / c
+		}
+	case InterpolationQuinn:
+		ap := real(xp / x0)
+		am := real(xm / x0)
+		dp := -ap / (1 - ap)
+		dm := am / (1 - am)
+		delta = (dp+dm)/2 + quinnTau(dp*dp) - quinnTau(dm*dm)
+	default:
+		return 0, fmt.Errorf("unknown interpolation method %d", method)
+	}
+
+	bin := float64(k) + delta
+	if bin >= float64(n)/2 {
+		bin -= float64(n)
+	}
+	return bin * sampleRate / float64(n), nil
+}
+
+// quinnTau - поправочная функция второго оценщика Куинна
+func quinnTau(x float64) float64 {
+	r := math.Sqrt(2.0 / 3.0)
+	return math.Log(3*x*x+6*x+1)/4 - math.Sqrt(6)/24*math.Log((x+1-r)/(x+1+r))
+}
@@ -0,0 +1,148 @@
+package detectors
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/Alexxtn105/dsp/generators"
+)
+
+// complexToneWithNoise формирует комплексный тон из косинусной и синусной составляющих
+// генератора эталонных сигналов и добавляет комплексный гауссов шум с заданным SNR
+func complexToneWithNoise(t *testing.T, freq, fs float64, n int, phase, snrDB float64, rng *rand.Rand) []complex128 {
+	t.Helper()
+
+	gen := generators.NewReferenceSignalGenerator()
+	gen.Frequency = freq
+	gen.SampleRate = fs
+	gen.TotalTime = float64(n) / fs
+	gen.Phase = phase
+
+	gen.SignalType = generators.Cosine
+	re, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("generate cosine: %v", err)
+	}
+	gen.SignalType = generators.Sine
+	im, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("generate sine: %v", err)
+	}
+
+	sigma := math.Sqrt(math.Pow(10, -snrDB/10) / 2) // СКО каждой квадратуры
+	x := make([]complex128, n)
+	for i := range x {
+		x[i] = complex(re[i]+sigma*rng.NormFloat64(), im[i]+sigma*rng.NormFloat64())
+	}
+	return x
+}
+
+// TestSingleToneEstimatorsCRLB сравнивает СКО оценщиков с границей Крамера-Рао в зависимости от SNR
+func TestSingleToneEstimatorsCRLB(t *testing.T) {
+	fs := 8000.0
+	n := 64
+	trials := 400
+
+	type estimator struct {
+		name     string
+		freq     float64 // Частота тона в пределах диапазона однозначности метода
+		minSNR   float64 // Порог SNR, выше которого проверяется эффективность
+		maxRatio float64 // Допустимое отношение MSE/CRLB
+		estimate func([]complex128) (float64, error)
+	}
+	small := 0.3 * fs / float64(n)
+	large := 10.3 * fs / float64(n)
+	estimators := []estimator{
+		{"Kay", large, 20, 1.5, func(x []complex128) (float64, error) { return EstimateFrequencyKay(x, fs) }},
+		{"Fitz", small, 0, 1.5, func(x []complex128) (float64, error) { return EstimateFrequencyFitz(x, fs, 0) }},
+		{"L&R", small, 0, 1.5, func(x []complex128) (float64, error) { return EstimateFrequencyLuiseReggiannini(x, fs, 0) }},
+		{"Quinn", large, 10, 2.0, func(x []complex128) (float64, error) { return EstimateFrequencyFFT(x, fs, InterpolationQuinn) }},
+		{"Candan", large, 10, 3.0, func(x []complex128) (float64, error) { return EstimateFrequencyFFT(x, fs, InterpolationCandan) }},
+		{"Jacobsen", large, 10, 3.0, func(x []complex128) (float64, error) { return EstimateFrequencyFFT(x, fs, InterpolationJacobsen) }},
+	}
+
+	for _, e := range estimators {
+		for _, snrDB := range []float64{0, 10, 20, 30} {
+			rng := rand.New(rand.NewSource(int64(snrDB) + 7))
+			var mse float64
+			for trial := 0; trial < trials; trial++ {
+				x := complexToneWithNoise(t, e.freq, fs, n, rng.Float64()*2*math.Pi, snrDB, rng)
+				f, err := e.estimate(x)
+				if err != nil {
+					t.Fatalf("%s: %v", e.name, err)
+				}
+				mse += (f - e.freq) * (f - e.freq)
+			}
+			mse /= float64(trials)
+
+			crlb := FrequencyCRLB(math.Pow(10, snrDB/10), n, fs)
+			ratio := mse / crlb
+			t.Logf("%-8s SNR %2.0f dB: MSE/CRLB = %.2f", e.name, snrDB, ratio)
+
+			// Несмещенная оценка не может быть заметно лучше границы
+			if ratio < 0.7 {
+				t.Errorf("%s at %.0f dB: MSE/CRLB %.2f is below the bound", e.name, snrDB, ratio)
+			}
+			if snrDB >= e.minSNR && ratio > e.maxRatio {
+				t.Errorf("%s at %.0f dB: MSE/CRLB %.2f exceeds %.2f", e.name, snrDB, ratio, e.maxRatio)
+			}
+		}
+	}
+}
+
+// TestEstimateFrequencyFFTNoiseless проверяет точность интерполяции без шума
+func TestEstimateFrequencyFFTNoiseless(t *testing.T) {
+	fs := 1000.0
+	n := 128
+	rng := rand.New(rand.NewSource(1))
+
+	for _, freq := range []float64{-123.4, 7.8, 250.1, 401.9} {
+		x := complexToneWithNoise(t, math.Abs(freq), fs, n, 0.4, 300, rng)
+		if freq < 0 {
+			for i := range x {
+				x[i] = complex(real(x[i]), -imag(x[i]))
+			}
+		}
+
+		tolerances := map[PeakInterpolation]float64{
+			InterpolationNone:     fs / float64(n) / 2,
+			InterpolationJacobsen: 0.1,
+			InterpolationQuinn:    0.01,
+			InterpolationCandan:   0.01,
+		}
+		for method, tol := range tolerances {
+			f, err := EstimateFrequencyFFT(x, fs, method)
+			if err != nil {
+				t.Fatalf("%s: %v", method, err)
+			}
+			if math.Abs(f-freq) > tol {
+				t.Errorf("%s: %f Hz, want %f ± %g", method, f, freq, tol)
+			}
+		}
+
+		if f, _ := EstimateFrequencyKay(x, fs); math.Abs(f-freq) > 1e-6 {
+			t.Errorf("Kay: %f Hz, want %f", f, freq)
+		}
+	}
+}
+
+// TestSingleToneEstimatorErrors проверяет проверку параметров
+func TestSingleToneEstimatorErrors(t *testing.T) {
+	x := make([]complex128, 16)
+	if _, err := EstimateFrequencyKay(x[:1], 1000); err == nil {
+		t.Error("expected error for short block")
+	}
+	if _, err := EstimateFrequencyFitz(x, 0, 0); err == nil {
+		t.Error("expected error for zero sample rate")
+	}
+	if _, err := EstimateFrequencyLuiseReggiannini(x, 1000, 16); err == nil {
+		t.Error("expected error for too many lags")
+	}
+	if _, err := EstimateFrequencyFFT(x, 1000, PeakInterpolation(9)); err == nil {
+		t.Error("expected error for unknown interpolation")
+	}
+	if !math.IsInf(FrequencyCRLB(0, 16, 1000), 1) {
+		t.Error("CRLB for zero SNR should be infinite")
+	}
+}
@@ -0,0 +1,229 @@
+package detectors
+
+import "math"
+
+// ToneEventType определяет тип события декодера тональных сигналов
+type ToneEventType int
+
+const (
+	KeyDown ToneEventType = iota // Начало посылки (символ обнаружен)
+	KeyUp                        // Окончание посылки
+)
+
+// String возвращает строковое представление типа события
+func (t ToneEventType) String() string {
+	switch t {
+	case KeyDown:
+		return "KeyDown"
+	case KeyUp:
+		return "KeyUp"
+	default:
+		return "Unknown"
+	}
+}
+
+// ToneEvent описывает событие декодера тональных сигналов
+type ToneEvent struct {
+	Type   ToneEventType // Тип события
+	Symbol rune          // Декодированный символ
+	Sample int           // Номер отсчета начала события (от начала потока)
+	Time   float64       // Время начала события в секундах
+}
+
+// sequencerEvent - событие логики длительностей с кодом символа
+type sequencerEvent struct {
+	eventType ToneEventType
+	code      int // Код символа (0 - отсутствие символа)
+	sample    int // Отсчет начала события
+}
+
+// toneSequencer накапливает отсчеты в блоки и реализует общую для тональных
+// декодеров логику длительностей: символ считается нажатым после requiredOn
+// подряд идущих блоков с одинаковым кодом и отпущенным после requiredOff блоков без него.
+// Короткие провалы внутри посылки (меньше requiredOff блоков) не разрывают ее.
+type toneSequencer struct {
+	requiredOn  int // Количество подряд идущих блоков для фиксации нажатия
+	requiredOff int // Количество блоков без символа для фиксации отпускания
+
+	block    []float64 // Накопитель текущего блока
+	blockPos int       // Заполненность блока
+	samples  int       // Количество отсчетов, обработанных до начала текущего блока
+
+	candidate      int // Код символа-кандидата
+	candidateCount int // Количество подряд идущих блоков с кандидатом
+	candidateStart int // Отсчет начала серии кандидата
+
+	down      bool // Символ нажат
+	current   int  // Код нажатого символа
+	missCount int  // Количество подряд идущих блоков без нажатого символа
+	missStart int  // Отсчет начала серии пропусков
+}
+
+// newToneSequencer создает логику длительностей для блоков длины blockSize
+func newToneSequencer(sampleRate float64, blockSize int, minTone, minPause float64) *toneSequencer {
+	return &toneSequencer{
+		requiredOn:  requiredBlocks(minTone, blockSize, sampleRate),
+		requiredOff: requiredBlocks(minPause, blockSize, sampleRate),
+		block:       make([]float64, blockSize),
+	}
+}
+
+// requiredBlocks вычисляет количество полных блоков, гарантированно укладывающихся в интервал
+// Интервал длительности duration при произвольном выравнивании целиком покрывает
+// не менее floor(duration/Tb) - 1 блоков.
+func requiredBlocks(duration float64, blockSize int, sampleRate float64) int {
+	blockDuration := float64(blockSize) / sampleRate
+	n := int(math.Floor(duration/blockDuration)) - 1
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// process разбивает отсчеты на блоки, анализирует каждый блок функцией analyze
+// и возвращает события нажатия и отпускания
+func (s *toneSequencer) process(samples []float64, analyze func(block []float64) int) []sequencerEvent {
+	var events []sequencerEvent
+	for _, x := range samples {
+		s.block[s.blockPos] = x
+		s.blockPos++
+		if s.blockPos < len(s.block) {
+			continue
+		}
+
+		events = s.update(analyze(s.block), s.samples, events)
+
+		s.samples += len(s.block)
+		s.blockPos = 0
+	}
+	return events
+}
+
+// update обновляет логику длительностей и добавляет события в events
+func (s *toneSequencer) update(code, blockStart int, events []sequencerEvent) []sequencerEvent {
+	if code != 0 && code == s.candidate {
+		s.candidateCount++
+	} else {
+		s.candidate = code
+		s.candidateCount = 1
+		s.candidateStart = blockStart
+	}
+
+	if s.down {
+		if code == s.current {
+			s.missCount = 0
+		} else {
+			if s.missCount == 0 {
+				s.missStart = blockStart
+			}
+			s.missCount++
+			if s.missCount >= s.requiredOff {
+				events = append(events, sequencerEvent{KeyUp, s.current, s.missStart})
+				s.down = false
+			}
+		}
+	}
+
+	if !s.down && s.candidate != 0 && s.candidateCount >= s.requiredOn {
+		events = append(events, sequencerEvent{KeyDown, s.candidate, s.candidateStart})
+		s.down = true
+		s.current = s.candidate
+		s.missCount = 0
+	}
+
+	return events
+}
+
+// flush завершает поток: если символ нажат, формирует событие отпускания
+func (s *toneSequencer) flush() []sequencerEvent {
+	if !s.down {
+		return nil
+	}
+	sample := s.samples + s.blockPos
+	if s.missCount > 0 {
+		sample = s.missStart
+	}
+	s.down = false
+	return []sequencerEvent{{KeyUp, s.current, sample}}
+}
+
+// reset сбрасывает состояние
+func (s *toneSequencer) reset() {
+	s.blockPos = 0
+	s.samples = 0
+	s.candidate = 0
+	s.candidateCount = 0
+	s.down = false
+	s.current = 0
+	s.missCount = 0
+}
+
+// active возвращает код нажатого символа и признак нажатия
+func (s *toneSequencer) active() (int, bool) {
+	return s.current, s.down
+}
+
+// toToneEvents преобразует события логики длительностей в события с символами и временем
+func toToneEvents(events []sequencerEvent, sampleRate float64) []ToneEvent {
+	if len(events) == 0 {
+		return nil
+	}
+	result := make([]ToneEvent, len(events))
+	for i, e := range events {
+		result[i] = ToneEvent{
+			Type:   e.eventType,
+			Symbol: rune(e.code),
+			Sample: e.sample,
+			Time:   float64(e.sample) / sampleRate,
+		}
+	}
+	return result
+}
+
+// interpolateTone уточняет частоту и амплитуду тона по трем бинам Герцеля
+// с шагом delta (Гц), центральный из которых настроен на номинальную частоту f.
+// Для прямоугольного окна смещение в долях шага равно |X±1| / (|X0| + |X±1|),
+// амплитуда корректируется на спад главного лепестка sin(πd)/(πd) для ближайшего бина.
+func interpolateTone(lower, center, upper, f, delta float64) (freq, amplitude float64) {
+	if center <= 0 {
+		return f, 0
+	}
+
+	var offset, side float64
+	if upper >= lower {
+		offset, side = upper/(center+upper), upper
+	} else {
+		offset, side = -lower/(center+lower), lower
+	}
+
+	// Расстояние до ближайшего из двух бинов не превышает половины шага
+	peak, distance := center, math.Abs(offset)
+	if distance > 0.5 {
+		peak, distance = side, 1-distance
+	}
+	amplitude = peak
+	if distance > 0 {
+		x := math.Pi * distance
+		amplitude = peak * x / math.Sin(x)
+	}
+	return f + offset*delta, amplitude
+}
+
+// argMax возвращает индекс наибольшего элемента
+func argMax(values []float64) int {
+	best := 0
+	for i, v := range values {
+		if v > values[best] {
+			best = i
+		}
+	}
+	return best
+}
+
+// toDB переводит отношение амплитуд в децибелы
+func toDB(ratio float64) float64 {
+	if ratio <= 0 {
+		return math.Inf(-1)
+	}
+	return 20 * math.Log10(ratio)
+}
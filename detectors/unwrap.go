@@ -0,0 +1,40 @@
+package detectors
+
+import "math"
+
+// WrapPhase приводит фазу к диапазону (-π, π]
+func WrapPhase(phase float64) float64 {
+	return normalizePhase(phase)
+}
+
+// WrapPhases приводит все значения фазы к диапазону (-π, π]
+// Возвращает новый срез, исходный не изменяется.
+func WrapPhases(phases []float64) []float64 {
+	result := make([]float64, len(phases))
+	for i, p := range phases {
+		result[i] = normalizePhase(p)
+	}
+	return result
+}
+
+// UnwrapPhases выполняет развертку фазы: устраняет скачки между соседними
+// отсчетами, превышающие π, добавляя кратные 2π. Первое значение сохраняется.
+// Значения NaN пропускаются и не влияют на развертку последующих отсчетов.
+// Возвращает новый срез, исходный не изменяется.
+func UnwrapPhases(phases []float64) []float64 {
+	result := make([]float64, len(phases))
+	var offset float64
+	prev := math.NaN()
+	for i, p := range phases {
+		if math.IsNaN(p) {
+			result[i] = p
+			continue
+		}
+		if !math.IsNaN(prev) {
+			offset -= 2 * math.Pi * math.Round((p+offset-prev)/(2*math.Pi))
+		}
+		result[i] = p + offset
+		prev = result[i]
+	}
+	return result
+}
@@ -0,0 +1,60 @@
+package detectors
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWrapPhases(t *testing.T) {
+	phases := []float64{0, math.Pi, -math.Pi, 3 * math.Pi / 2, -5 * math.Pi / 2, 7}
+	want := []float64{0, math.Pi, math.Pi, -math.Pi / 2, -math.Pi / 2, 7 - 2*math.Pi}
+	got := WrapPhases(phases)
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-12 {
+			t.Errorf("WrapPhases()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+	if phases[3] != 3*math.Pi/2 {
+		t.Error("WrapPhases() modified input slice")
+	}
+	if w := WrapPhase(-math.Pi); w != math.Pi {
+		t.Errorf("WrapPhase(-π) = %v, want π", w)
+	}
+}
+
+func TestUnwrapPhases(t *testing.T) {
+	// Линейно нарастающая фаза 10 оборотов
+	n := 200
+	ramp := make([]float64, n)
+	for i := range ramp {
+		ramp[i] = -1 + 0.3*float64(i)
+	}
+	got := UnwrapPhases(WrapPhases(ramp))
+	offset := got[0] - ramp[0]
+	for i := range ramp {
+		if math.Abs(got[i]-ramp[i]-offset) > 1e-9 {
+			t.Fatalf("UnwrapPhases()[%d] = %v, want %v", i, got[i], ramp[i]+offset)
+		}
+	}
+
+	// Убывающая фаза с переходом через -π и пропуском NaN
+	wrapped := WrapPhases([]float64{-2.5, -3, -3.5, -4, -4.5, -5})
+	wrapped[2] = math.NaN()
+	got = UnwrapPhases(wrapped)
+	want := []float64{-2.5, -3, math.NaN(), -4, -4.5, -5}
+	for i := range want {
+		if math.IsNaN(want[i]) {
+			if !math.IsNaN(got[i]) {
+				t.Errorf("UnwrapPhases()[%d] = %v, want NaN", i, got[i])
+			}
+			continue
+		}
+		if math.Abs(got[i]-want[i]) > 1e-12 {
+			t.Errorf("UnwrapPhases()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if len(UnwrapPhases(nil)) != 0 {
+		t.Error("UnwrapPhases(nil) should be empty")
+	}
+}
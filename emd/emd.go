@@ -0,0 +1,404 @@
+// Package emd реализует эмпирическую модовую декомпозицию (EMD), ее ансамблевый
+// вариант (EEMD) и спектр Гильберта-Хуанга для анализа нестационарных сигналов
+package emd
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// StopCriterion - критерий остановки просеивания (sifting)
+type StopCriterion int
+
+const (
+	// StopSD - нормированное среднеквадратичное отличие соседних итераций (Huang, 1998):
+	// SD = Σ(h[k-1] - h[k])² / Σh[k-1]² < SDThreshold
+	StopSD StopCriterion = iota
+	// StopSNumber - число экстремумов и нулей отличается не более чем на 1
+	// и не меняется SNumber итераций подряд (Huang, 2003)
+	StopSNumber
+	// StopFixed - фиксированное число итераций MaxSiftIterations
+	StopFixed
+)
+
+// String возвращает название критерия
+func (c StopCriterion) String() string {
+	switch c {
+	case StopSD:
+		return "SD"
+	case StopSNumber:
+		return "S-number"
+	case StopFixed:
+		return "Fixed"
+	default:
+		return "Unknown"
+	}
+}
+
+// Config - параметры декомпозиции
+type Config struct {
+	MaxIMFs           int           // Максимальное число мод (0 - без ограничения)
+	MaxSiftIterations int           // Максимальное число итераций просеивания на одну моду
+	Criterion         StopCriterion // Критерий остановки просеивания
+	SDThreshold       float64       // Порог для StopSD (обычно 0.2-0.3)
+	SNumber           int           // Число устойчивых итераций для StopSNumber (обычно 3-5)
+}
+
+// DefaultConfig возвращает параметры по умолчанию: критерий SD с порогом 0.2
+func DefaultConfig() Config {
+	return Config{
+		MaxIMFs:           0,
+		MaxSiftIterations: 100,
+		Criterion:         StopSD,
+		SDThreshold:       0.2,
+		SNumber:           4,
+	}
+}
+
+// EnsembleConfig - параметры ансамблевой декомпозиции
+type EnsembleConfig struct {
+	Config
+	Ensembles int     // Число реализаций шума
+	NoiseStd  float64 // СКО добавляемого шума относительно СКО сигнала (обычно 0.1-0.4)
+	Seed      int64   // Начальное значение генератора шума
+}
+
+// DefaultEnsembleConfig возвращает параметры EEMD по умолчанию (100 реализаций, шум 0.2)
+func DefaultEnsembleConfig() EnsembleConfig {
+	return EnsembleConfig{
+		Config:    DefaultConfig(),
+		Ensembles: 100,
+		NoiseStd:  0.2,
+		Seed:      1,
+	}
+}
+
+// Result - результат декомпозиции
+// Сумма всех мод и остатка равна исходному сигналу.
+type Result struct {
+	IMFs       [][]float64 // Внутренние модовые функции (от высоких частот к низким)
+	Residue    []float64   // Остаток (тренд)
+	Iterations []int       // Число итераций просеивания для каждой моды (для EEMD - среднее)
+}
+
+// validate проверяет параметры декомпозиции
+func (c Config) validate() error {
+	if c.MaxIMFs < 0 {
+		return fmt.Errorf("max IMFs must be non-negative, got %d", c.MaxIMFs)
+	}
+	if c.MaxSiftIterations < 1 {
+		return fmt.Errorf("max sift iterations must be positive, got %d", c.MaxSiftIterations)
+	}
+	switch c.Criterion {
+	case StopSD:
+		if c.SDThreshold <= 0 {
+			return fmt.Errorf("SD threshold must be positive, got %f", c.SDThreshold)
+		}
+	case StopSNumber:
+		if c.SNumber < 1 {
+			return fmt.Errorf("S-number must be positive, got %d", c.SNumber)
+		}
+	case StopFixed:
+	default:
+		return fmt.Errorf("unknown stop criterion %d", c.Criterion)
+	}
+	return nil
+}
+
+// Decompose выполняет эмпирическую модовую декомпозицию сигнала
+// Огибающие строятся естественными кубическими сплайнами по локальным экстремумам;
+// для ослабления краевых эффектов крайние экстремумы зеркально отражаются
+// относительно границ сигнала. Декомпозиция завершается, когда остаток
+// становится монотонным (менее двух максимумов или минимумов) или достигнуто MaxIMFs.
+func Decompose(signal []float64, cfg Config) (*Result, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	if len(signal) < 4 {
+		return nil, fmt.Errorf("signal must contain at least 4 samples, got %d", len(signal))
+	}
+	return decompose(signal, cfg, cfg.MaxIMFs), nil
+}
+
+// decompose выполняет декомпозицию на не более чем maxIMFs мод (0 - без ограничения)
+func decompose(signal []float64, cfg Config, maxIMFs int) *Result {
+	residue := make([]float64, len(signal))
+	copy(residue, signal)
+
+	result := &Result{}
+	for maxIMFs == 0 || len(result.IMFs) < maxIMFs {
+		maxima, minima := findExtrema(residue)
+		if len(maxima) < 2 || len(minima) < 2 {
+			break
+		}
+
+		imf, iterations := sift(residue, cfg)
+		for i := range residue {
+			residue[i] -= imf[i]
+		}
+		result.IMFs = append(result.IMFs, imf)
+		result.Iterations = append(result.Iterations, iterations)
+	}
+	result.Residue = residue
+	return result
+}
+
+// sift выделяет одну модовую функцию из сигнала
+func sift(signal []float64, cfg Config) ([]float64, int) {
+	n := len(signal)
+	h := make([]float64, n)
+	copy(h, signal)
+	next := make([]float64, n)
+
+	stable := 0
+	prevExtrema, prevZeros := -1, -1
+	iterations := 0
+	for iterations < cfg.MaxSiftIterations {
+		maxima, minima := findExtrema(h)
+		if len(maxima) < 2 || len(minima) < 2 {
+			break
+		}
+		upper := envelope(h, maxima)
+		lower := envelope(h, minima)
+
+		var diff, norm float64
+		for i := range h {
+			next[i] = h[i] - (upper[i]+lower[i])/2
+			d := h[i] - next[i]
+			diff += d * d
+			norm += h[i] * h[i]
+		}
+		h, next = next, h
+		iterations++
+
+		switch cfg.Criterion {
+		case StopSD:
+			if norm == 0 || diff/norm < cfg.SDThreshold {
+				return h, iterations
+			}
+		case StopSNumber:
+			maxima, minima = findExtrema(h)
+			extrema := len(maxima) + len(minima)
+			zeros := zeroCrossings(h)
+			if abs(extrema-zeros) <= 1 && extrema == prevExtrema && zeros == prevZeros {
+				stable++
+				if stable >= cfg.SNumber {
+					return h, iterations
+				}
+			} else {
+				stable = 0
+			}
+			prevExtrema, prevZeros = extrema, zeros
+		}
+	}
+	return h, iterations
+}
+
+// EnsembleDecompose выполняет ансамблевую декомпозицию (EEMD, Wu & Huang, 2009)
+// К сигналу добавляются независимые реализации белого гауссова шума, каждая
+// реализация раскладывается EMD, и моды с одинаковыми номерами усредняются.
+// Шум воспроизводим при одинаковом Seed. Число мод ограничивается MaxIMFs или,
+// если оно равно 0, величиной floor(log2(N)) - 1; остаток вычисляется как разность
+// сигнала и суммы мод.
+func EnsembleDecompose(signal []float64, cfg EnsembleConfig) (*Result, error) {
+	if err := cfg.Config.validate(); err != nil {
+		return nil, err
+	}
+	if len(signal) < 4 {
+		return nil, fmt.Errorf("signal must contain at least 4 samples, got %d", len(signal))
+	}
+	if cfg.Ensembles < 1 {
+		return nil, fmt.Errorf("ensembles must be positive, got %d", cfg.Ensembles)
+	}
+	if cfg.NoiseStd < 0 {
+		return nil, fmt.Errorf("noise std must be non-negative, got %f", cfg.NoiseStd)
+	}
+
+	n := len(signal)
+	maxIMFs := cfg.MaxIMFs
+	if maxIMFs == 0 {
+		maxIMFs = int(math.Log2(float64(n))) - 1
+	}
+
+	sigma := cfg.NoiseStd * stdDev(signal)
+	rng := rand.New(rand.NewSource(cfg.Seed))
+
+	imfs := make([][]float64, maxIMFs)
+	for k := range imfs {
+		imfs[k] = make([]float64, n)
+	}
+	iterations := make([]int, maxIMFs)
+	noisy := make([]float64, n)
+
+	for e := 0; e < cfg.Ensembles; e++ {
+		for i := range noisy {
+			noisy[i] = signal[i] + sigma*rng.NormFloat64()
+		}
+		trial := decompose(noisy, cfg.Config, maxIMFs)
+		for k, imf := range trial.IMFs {
+			for i := range imf {
+				imfs[k][i] += imf[i]
+			}
+			iterations[k] += trial.Iterations[k]
+		}
+	}
+
+	result := &Result{Residue: make([]float64, n)}
+	copy(result.Residue, signal)
+	scale := 1 / float64(cfg.Ensembles)
+	for k := range imfs {
+		if iterations[k] == 0 {
+			break // Ни одна реализация не дала моду с этим номером
+		}
+		for i := range imfs[k] {
+			imfs[k][i] *= scale
+			result.Residue[i] -= imfs[k][i]
+		}
+		result.IMFs = append(result.IMFs, imfs[k])
+		result.Iterations = append(result.Iterations, iterations[k]/cfg.Ensembles)
+	}
+	return result, nil
+}
+
+// findExtrema находит индексы локальных максимумов и минимумов
+// Для плато берется его середина.
+func findExtrema(x []float64) ([]int, []int) {
+	var maxima, minima []int
+	n := len(x)
+	for i := 1; i < n-1; i++ {
+		if x[i] == x[i-1] {
+			continue
+		}
+		// Пропускаем плато
+		j := i
+		for j < n-1 && x[j+1] == x[i] {
+			j++
+		}
+		if j == n-1 {
+			break
+		}
+		mid := (i + j) / 2
+		if x[i] > x[i-1] && x[i] > x[j+1] {
+			maxima = append(maxima, mid)
+		} else if x[i] < x[i-1] && x[i] < x[j+1] {
+			minima = append(minima, mid)
+		}
+		i = j
+	}
+	return maxima, minima
+}
+
+// envelope строит огибающую по экстремумам естественным кубическим сплайном
+// Первый и последний экстремумы зеркально отражаются относительно границ сигнала.
+func envelope(x []float64, extrema []int) []float64 {
+	n := len(x)
+	first, last := extrema[0], extrema[len(extrema)-1]
+
+	knots := make([]float64, 0, len(extrema)+2)
+	values := make([]float64, 0, len(extrema)+2)
+	if first != 0 {
+		knots = append(knots, -float64(first))
+		values = append(values, x[first])
+	}
+	for _, e := range extrema {
+		knots = append(knots, float64(e))
+		values = append(values, x[e])
+	}
+	if last != n-1 {
+		knots = append(knots, float64(2*(n-1)-last))
+		values = append(values, x[last])
+	}
+
+	spline := newCubicSpline(knots, values)
+	env := make([]float64, n)
+	for i := range env {
+		env[i] = spline.eval(float64(i))
+	}
+	return env
+}
+
+// cubicSpline - естественный кубический сплайн
+type cubicSpline struct {
+	x, y, m []float64 // Узлы, значения, вторые производные
+}
+
+// newCubicSpline строит естественный кубический сплайн (методом прогонки)
+func newCubicSpline(x, y []float64) *cubicSpline {
+	n := len(x)
+	m := make([]float64, n)
+	if n > 2 {
+		// Трехдиагональная система для вторых производных внутренних узлов
+		c := make([]float64, n)
+		d := make([]float64, n)
+		for i := 1; i < n-1; i++ {
+			h0 := x[i] - x[i-1]
+			h1 := x[i+1] - x[i]
+			a := h0 / 6
+			b := (h0 + h1) / 3
+			cc := h1 / 6
+			r := (y[i+1]-y[i])/h1 - (y[i]-y[i-1])/h0
+			den := b - a*c[i-1]
+			c[i] = cc / den
+			d[i] = (r - a*d[i-1]) / den
+		}
+		for i := n - 2; i >= 1; i-- {
+			m[i] = d[i] - c[i]*m[i+1]
+		}
+	}
+	return &cubicSpline{x: x, y: y, m: m}
+}
+
+// eval вычисляет значение сплайна в точке t
+func (s *cubicSpline) eval(t float64) float64 {
+	n := len(s.x)
+	// Бинарный поиск интервала
+	lo, hi := 0, n-1
+	for hi-lo > 1 {
+		mid := (lo + hi) / 2
+		if s.x[mid] > t {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+
+	h := s.x[hi] - s.x[lo]
+	a := (s.x[hi] - t) / h
+	b := (t - s.x[lo]) / h
+	return a*s.y[lo] + b*s.y[hi] + ((a*a*a-a)*s.m[lo]+(b*b*b-b)*s.m[hi])*h*h/6
+}
+
+// zeroCrossings подсчитывает число переходов через ноль
+func zeroCrossings(x []float64) int {
+	count := 0
+	for i := 1; i < len(x); i++ {
+		if (x[i-1] < 0 && x[i] >= 0) || (x[i-1] >= 0 && x[i] < 0) {
+			count++
+		}
+	}
+	return count
+}
+
+// stdDev вычисляет СКО сигнала
+func stdDev(x []float64) float64 {
+	var mean float64
+	for _, v := range x {
+		mean += v
+	}
+	mean /= float64(len(x))
+
+	var sum float64
+	for _, v := range x {
+		sum += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(sum / float64(len(x)))
+}
+
+// abs возвращает модуль целого числа
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,136 @@
+package emd
+
+import (
+	"math"
+	"testing"
+)
+
+// testSignal создает сумму двух тонов и линейного тренда
+func testSignal(n int, fs float64) ([]float64, []float64, []float64) {
+	fast := make([]float64, n)
+	slow := make([]float64, n)
+	x := make([]float64, n)
+	for i := range x {
+		ti := float64(i) / fs
+		fast[i] = math.Sin(2 * math.Pi * 50 * ti)
+		slow[i] = 2 * math.Sin(2*math.Pi*5*ti)
+		x[i] = fast[i] + slow[i] + 0.5*ti
+	}
+	return x, fast, slow
+}
+
+// correlation вычисляет коэффициент корреляции двух сигналов на отрезке [from, to)
+func correlation(a, b []float64, from, to int) float64 {
+	var ab, aa, bb float64
+	for i := from; i < to; i++ {
+		ab += a[i] * b[i]
+		aa += a[i] * a[i]
+		bb += b[i] * b[i]
+	}
+	return ab / math.Sqrt(aa*bb)
+}
+
+// TestDecompose проверяет разделение тонов и полноту декомпозиции
+func TestDecompose(t *testing.T) {
+	fs := 1000.0
+	x, fast, slow := testSignal(2000, fs)
+
+	for _, criterion := range []StopCriterion{StopSD, StopSNumber, StopFixed} {
+		cfg := DefaultConfig()
+		cfg.Criterion = criterion
+		if criterion == StopFixed {
+			cfg.MaxSiftIterations = 10
+		}
+		result, err := Decompose(x, cfg)
+		if err != nil {
+			t.Fatalf("%s: Decompose failed: %v", criterion, err)
+		}
+		if len(result.IMFs) < 2 {
+			t.Fatalf("%s: expected at least 2 IMFs, got %d", criterion, len(result.IMFs))
+		}
+
+		// Сумма мод и остатка восстанавливает сигнал
+		for i := range x {
+			sum := result.Residue[i]
+			for _, imf := range result.IMFs {
+				sum += imf[i]
+			}
+			if math.Abs(sum-x[i]) > 1e-9 {
+				t.Fatalf("%s: reconstruction error at %d", criterion, i)
+			}
+		}
+
+		if c := correlation(result.IMFs[0], fast, 200, 1800); c < 0.98 {
+			t.Errorf("%s: IMF 1 correlation with 50 Hz tone %f", criterion, c)
+		}
+		if c := correlation(result.IMFs[1], slow, 200, 1800); c < 0.95 {
+			t.Errorf("%s: IMF 2 correlation with 5 Hz tone %f", criterion, c)
+		}
+	}
+}
+
+// TestEnsembleDecompose проверяет воспроизводимость и полноту EEMD
+func TestEnsembleDecompose(t *testing.T) {
+	fs := 1000.0
+	x, _, slow := testSignal(1000, fs)
+
+	cfg := DefaultEnsembleConfig()
+	cfg.Ensembles = 20
+	a, err := EnsembleDecompose(x, cfg)
+	if err != nil {
+		t.Fatalf("EnsembleDecompose failed: %v", err)
+	}
+	b, _ := EnsembleDecompose(x, cfg)
+	for k := range a.IMFs {
+		for i := range a.IMFs[k] {
+			if a.IMFs[k][i] != b.IMFs[k][i] {
+				t.Fatalf("results differ for the same seed (IMF %d, sample %d)", k, i)
+			}
+		}
+	}
+
+	for i := range x {
+		sum := a.Residue[i]
+		for _, imf := range a.IMFs {
+			sum += imf[i]
+		}
+		if math.Abs(sum-x[i]) > 1e-9 {
+			t.Fatalf("reconstruction error at %d", i)
+		}
+	}
+
+	// Медленный тон должен попасть в одну из мод
+	best := 0.0
+	for _, imf := range a.IMFs {
+		best = math.Max(best, correlation(imf, slow, 100, 900))
+	}
+	if best < 0.9 {
+		t.Errorf("best correlation with 5 Hz tone %f", best)
+	}
+}
+
+// TestDecomposeErrors проверяет проверку параметров
+func TestDecomposeErrors(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5}
+
+	cfg := DefaultConfig()
+	cfg.SDThreshold = 0
+	if _, err := Decompose(x, cfg); err == nil {
+		t.Error("expected error for zero SD threshold")
+	}
+	if _, err := Decompose(x[:3], DefaultConfig()); err == nil {
+		t.Error("expected error for short signal")
+	}
+
+	// Монотонный сигнал не содержит мод
+	result, err := Decompose(x, DefaultConfig())
+	if err != nil || len(result.IMFs) != 0 {
+		t.Errorf("monotonic signal: %v, %d IMFs", err, len(result.IMFs))
+	}
+
+	ecfg := DefaultEnsembleConfig()
+	ecfg.Ensembles = 0
+	if _, err := EnsembleDecompose(x, ecfg); err == nil {
+		t.Error("expected error for zero ensembles")
+	}
+}
@@ -0,0 +1,97 @@
+package emd
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+
+	"github.com/Alexxtn105/dsp/hilbert"
+)
+
+// HilbertSpectrum - частотно-временной спектр Гильберта-Хуанга
+type HilbertSpectrum struct {
+	Times       []float64   // Моменты времени отсчетов (с)
+	Frequencies []float64   // Центры частотных бинов (Гц)
+	Energy      [][]float64 // Энергия (квадрат мгновенной амплитуды) [бин][отсчет]
+	Marginal    []float64   // Маргинальный спектр: сумма энергии по времени для каждого бина
+}
+
+// InstantaneousAttributes вычисляет мгновенную амплитуду и частоту (Гц) модовой функции
+// Аналитический сигнал строится через БПФ с дополнением нулями (hilbert.AnalyticSignalPadded),
+// частота - по приращению фазы между соседними отсчетами (первый отсчет дублирует второй).
+func InstantaneousAttributes(imf []float64, sampleRate float64) ([]float64, []float64, error) {
+	if sampleRate <= 0 {
+		return nil, nil, fmt.Errorf("sample rate must be positive, got %f", sampleRate)
+	}
+	if len(imf) < 2 {
+		return nil, nil, fmt.Errorf("IMF must contain at least 2 samples, got %d", len(imf))
+	}
+
+	z, err := hilbert.AnalyticSignalPadded(imf, len(imf))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	amplitude := make([]float64, len(z))
+	frequency := make([]float64, len(z))
+	for i := range z {
+		amplitude[i] = cmplx.Abs(z[i])
+		if i > 0 {
+			frequency[i] = cmplx.Phase(z[i]*cmplx.Conj(z[i-1])) * sampleRate / (2 * math.Pi)
+		}
+	}
+	frequency[0] = frequency[1]
+	return amplitude, frequency, nil
+}
+
+// HilbertHuangSpectrum строит спектр Гильберта-Хуанга по модовым функциям
+// Для каждого отсчета каждой моды энергия a²[n] помещается в бин, соответствующий
+// мгновенной частоте. Частотная ось [0, maxFreq] делится на numBins равных бинов;
+// отсчеты с частотой вне диапазона (в том числе отрицательной) отбрасываются.
+func HilbertHuangSpectrum(imfs [][]float64, sampleRate float64, numBins int, maxFreq float64) (*HilbertSpectrum, error) {
+	if len(imfs) == 0 {
+		return nil, fmt.Errorf("at least one IMF is required")
+	}
+	if numBins < 1 {
+		return nil, fmt.Errorf("number of bins must be positive, got %d", numBins)
+	}
+	if maxFreq <= 0 || maxFreq > sampleRate/2 {
+		return nil, fmt.Errorf("max frequency must be in (0, %f], got %f", sampleRate/2, maxFreq)
+	}
+
+	n := len(imfs[0])
+	spectrum := &HilbertSpectrum{
+		Times:       make([]float64, n),
+		Frequencies: make([]float64, numBins),
+		Energy:      make([][]float64, numBins),
+		Marginal:    make([]float64, numBins),
+	}
+	for i := range spectrum.Times {
+		spectrum.Times[i] = float64(i) / sampleRate
+	}
+	binWidth := maxFreq / float64(numBins)
+	for b := range spectrum.Energy {
+		spectrum.Frequencies[b] = (float64(b) + 0.5) * binWidth
+		spectrum.Energy[b] = make([]float64, n)
+	}
+
+	for k, imf := range imfs {
+		if len(imf) != n {
+			return nil, fmt.Errorf("IMF %d has %d samples, expected %d", k, len(imf), n)
+		}
+		amplitude, frequency, err := InstantaneousAttributes(imf, sampleRate)
+		if err != nil {
+			return nil, err
+		}
+		for i := range imf {
+			if frequency[i] < 0 || frequency[i] >= maxFreq {
+				continue
+			}
+			b := int(frequency[i] / binWidth)
+			e := amplitude[i] * amplitude[i]
+			spectrum.Energy[b][i] += e
+			spectrum.Marginal[b] += e
+		}
+	}
+	return spectrum, nil
+}
@@ -0,0 +1,68 @@
+package emd
+
+import (
+	"math"
+	"testing"
+)
+
+// TestHilbertHuangSpectrum проверяет положение энергии на частотной оси
+func TestHilbertHuangSpectrum(t *testing.T) {
+	fs := 1000.0
+	x, _, _ := testSignal(2000, fs)
+
+	result, err := Decompose(x, DefaultConfig())
+	if err != nil {
+		t.Fatalf("Decompose failed: %v", err)
+	}
+
+	spectrum, err := HilbertHuangSpectrum(result.IMFs, fs, 100, 100)
+	if err != nil {
+		t.Fatalf("HilbertHuangSpectrum failed: %v", err)
+	}
+	if len(spectrum.Times) != len(x) || len(spectrum.Energy) != 100 {
+		t.Fatalf("unexpected spectrum size")
+	}
+
+	// Маргинальный спектр имеет максимумы в окрестности 5 и 50 Гц
+	peak := func(from, to float64) float64 {
+		best, freq := 0.0, 0.0
+		for b, f := range spectrum.Frequencies {
+			if f >= from && f < to && spectrum.Marginal[b] > best {
+				best, freq = spectrum.Marginal[b], f
+			}
+		}
+		return freq
+	}
+	if f := peak(20, 100); math.Abs(f-50) > 2 {
+		t.Errorf("fast component peak at %f Hz, want 50", f)
+	}
+	if f := peak(0, 20); math.Abs(f-5) > 2 {
+		t.Errorf("slow component peak at %f Hz, want 5", f)
+	}
+}
+
+// TestInstantaneousAttributes проверяет мгновенные амплитуду и частоту тона
+func TestInstantaneousAttributes(t *testing.T) {
+	fs := 1000.0
+	x := make([]float64, 1000)
+	for i := range x {
+		x[i] = 0.7 * math.Cos(2*math.Pi*37*float64(i)/fs)
+	}
+
+	amplitude, frequency, err := InstantaneousAttributes(x, fs)
+	if err != nil {
+		t.Fatalf("InstantaneousAttributes failed: %v", err)
+	}
+	for i := 100; i < 900; i++ {
+		if math.Abs(amplitude[i]-0.7) > 0.02 || math.Abs(frequency[i]-37) > 0.5 {
+			t.Fatalf("sample %d: amplitude %f, frequency %f", i, amplitude[i], frequency[i])
+		}
+	}
+
+	if _, err := HilbertHuangSpectrum(nil, fs, 10, 100); err == nil {
+		t.Error("expected error for empty IMF list")
+	}
+	if _, err := HilbertHuangSpectrum([][]float64{x}, fs, 10, 600); err == nil {
+		t.Error("expected error for max frequency above Nyquist")
+	}
+}
@@ -1 +1,157 @@
 package fft
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// FFT выполняет прямое дискретное преобразование Фурье
+// X[k] = sum x[n] * e^(-2πjkn/N)
+// Для длины, равной степени двойки, используется итеративный алгоритм Кули-Тьюки,
+// для остальных длин - алгоритм Блюстейна (через свертку степени двойки).
+// Входной срез не изменяется.
+func FFT(x []complex128) []complex128 {
+	result := make([]complex128, len(x))
+	copy(result, x)
+	transform(result, false)
+	return result
+}
+
+// IFFT выполняет обратное дискретное преобразование Фурье с нормировкой 1/N
+// x[n] = (1/N) * sum X[k] * e^(2πjkn/N)
+func IFFT(x []complex128) []complex128 {
+	result := make([]complex128, len(x))
+	copy(result, x)
+	transform(result, true)
+
+	scale := complex(1/float64(len(result)), 0)
+	for i := range result {
+		result[i] *= scale
+	}
+	return result
+}
+
+// FFTReal выполняет прямое преобразование Фурье действительного сигнала
+// Возвращает полный комплексный спектр длины len(x)
+func FFTReal(x []float64) []complex128 {
+	result := make([]complex128, len(x))
+	for i, v := range x {
+		result[i] = complex(v, 0)
+	}
+	transform(result, false)
+	return result
+}
+
+// IsPowerOfTwo проверяет, является ли число степенью двойки
+func IsPowerOfTwo(n int) bool {
+	return n > 0 && n&(n-1) == 0
+}
+
+// NextPowerOfTwo возвращает наименьшую степень двойки, не меньшую n
+func NextPowerOfTwo(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
+
+// transform выполняет преобразование на месте без нормировки
+// inverse = true - обратное преобразование (положительный знак экспоненты)
+func transform(x []complex128, inverse bool) {
+	n := len(x)
+	if n <= 1 {
+		return
+	}
+	if IsPowerOfTwo(n) {
+		radix2(x, inverse)
+		return
+	}
+	bluestein(x, inverse)
+}
+
+// radix2 реализует итеративное БПФ по основанию 2 на месте
+func radix2(x []complex128, inverse bool) {
+	n := len(x)
+
+	// Бит-реверсная перестановка
+	j := 0
+	for i := 1; i < n; i++ {
+		bit := n >> 1
+		for j&bit != 0 {
+			j ^= bit
+			bit >>= 1
+		}
+		j |= bit
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+
+	sign := -1.0
+	if inverse {
+		sign = 1.0
+	}
+
+	// Вычисления по бабочке
+	for length := 2; length <= n; length <<= 1 {
+		half := length >> 1
+		angle := sign * 2 * math.Pi / float64(length)
+		wStep := complex(math.Cos(angle), math.Sin(angle))
+
+		for start := 0; start < n; start += length {
+			w := complex(1, 0)
+			for k := 0; k < half; k++ {
+				t := x[start+k+half] * w
+				x[start+k+half] = x[start+k] - t
+				x[start+k] += t
+				w *= wStep
+			}
+		}
+	}
+}
+
+// bluestein реализует БПФ произвольной длины через свертку (алгоритм Блюстейна)
+// X[k] = conj(c[k]) * sum (x[n] * conj(c[n])) * c[k-n], где c[n] = e^(jπn²/N)
+func bluestein(x []complex128, inverse bool) {
+	n := len(x)
+	m := NextPowerOfTwo(2*n - 1)
+
+	sign := 1.0
+	if inverse {
+		sign = -1.0
+	}
+
+	// Чирп-последовательность; n² берется по модулю 2N для точности при больших n
+	chirp := make([]complex128, n)
+	for i := 0; i < n; i++ {
+		k := (i * i) % (2 * n)
+		angle := sign * math.Pi * float64(k) / float64(n)
+		chirp[i] = cmplx.Exp(complex(0, angle))
+	}
+
+	a := make([]complex128, m)
+	for i := 0; i < n; i++ {
+		a[i] = x[i] * cmplx.Conj(chirp[i])
+	}
+
+	b := make([]complex128, m)
+	b[0] = chirp[0]
+	for i := 1; i < n; i++ {
+		b[i] = chirp[i]
+		b[m-i] = chirp[i]
+	}
+
+	// Циклическая свертка через БПФ степени двойки
+	radix2(a, false)
+	radix2(b, false)
+	for i := range a {
+		a[i] *= b[i]
+	}
+	radix2(a, true)
+
+	scale := complex(1/float64(m), 0)
+	for i := 0; i < n; i++ {
+		x[i] = a[i] * scale * cmplx.Conj(chirp[i])
+	}
+}
@@ -0,0 +1,79 @@
+package fft
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+// naiveDFT вычисляет ДПФ по определению
+func naiveDFT(x []complex128) []complex128 {
+	n := len(x)
+	result := make([]complex128, n)
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			angle := -2 * math.Pi * float64(k*i) / float64(n)
+			result[k] += x[i] * cmplx.Exp(complex(0, angle))
+		}
+	}
+	return result
+}
+
+// TestFFTMatchesDFT сравнивает БПФ с прямым ДПФ для степеней двойки и произвольных длин
+func TestFFTMatchesDFT(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for _, n := range []int{1, 2, 8, 64, 3, 5, 12, 100, 205} {
+		x := make([]complex128, n)
+		for i := range x {
+			x[i] = complex(rng.NormFloat64(), rng.NormFloat64())
+		}
+
+		got := FFT(x)
+		want := naiveDFT(x)
+		for k := range want {
+			if cmplx.Abs(got[k]-want[k]) > 1e-9*float64(n) {
+				t.Fatalf("N=%d: X[%d] = %v, want %v", n, k, got[k], want[k])
+			}
+		}
+
+		// Обратное преобразование восстанавливает сигнал
+		back := IFFT(got)
+		for i := range x {
+			if cmplx.Abs(back[i]-x[i]) > 1e-9 {
+				t.Fatalf("N=%d: IFFT(FFT(x))[%d] = %v, want %v", n, i, back[i], x[i])
+			}
+		}
+	}
+}
+
+// TestFFTReal проверяет спектр действительной синусоиды
+func TestFFTReal(t *testing.T) {
+	n := 64
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = math.Cos(2 * math.Pi * 5 * float64(i) / float64(n))
+	}
+
+	spectrum := FFTReal(x)
+	for k, v := range spectrum {
+		want := 0.0
+		if k == 5 || k == n-5 {
+			want = float64(n) / 2
+		}
+		if math.Abs(cmplx.Abs(v)-want) > 1e-9 {
+			t.Errorf("|X[%d]| = %f, want %f", k, cmplx.Abs(v), want)
+		}
+	}
+}
+
+// TestPowerOfTwoHelpers проверяет вспомогательные функции
+func TestPowerOfTwoHelpers(t *testing.T) {
+	if !IsPowerOfTwo(1024) || IsPowerOfTwo(1000) || IsPowerOfTwo(0) {
+		t.Error("IsPowerOfTwo returned wrong result")
+	}
+	if NextPowerOfTwo(1000) != 1024 || NextPowerOfTwo(1024) != 1024 || NextPowerOfTwo(1) != 1 {
+		t.Error("NextPowerOfTwo returned wrong result")
+	}
+}
@@ -0,0 +1,400 @@
+package filters
+
+import (
+	"math"
+)
+
+// AdaptiveFilter описывает адаптивный КИХ-фильтр с пошаговой адаптацией
+type AdaptiveFilter interface {
+	// Adapt обрабатывает входной отсчет, сравнивает выход с желаемым сигналом и обновляет веса.
+	// Возвращает выход фильтра (до обновления весов) и ошибку desired - output.
+	Adapt(input, desired float64) (output, errSignal float64)
+	// GetWeights возвращает копию текущих весов фильтра
+	GetWeights() []float64
+	// Reset сбрасывает веса и состояние фильтра
+	Reset()
+}
+
+// LMSVariant определяет вариант алгоритма наименьших квадратов
+type LMSVariant int
+
+const (
+	StandardLMS   LMSVariant = iota // Классический LMS
+	NormalizedLMS                   // Нормированный LMS (NLMS)
+	LeakyLMS                        // LMS с утечкой
+)
+
+// LMSFilter реализует адаптивный фильтр по алгоритму LMS и его модификациям
+// Обновление весов: w = (1 - mu*leakage) * w + step * e * x,
+// где step = mu для LMS и mu / (eps + x^T x) для NLMS.
+type LMSFilter struct {
+	variant LMSVariant // Вариант алгоритма
+	mu      float64    // Шаг адаптации
+	leakage float64    // Коэффициент утечки (только LeakyLMS)
+	eps     float64    // Регуляризация нормировки (только NLMS)
+
+	weights []float64 // Веса фильтра
+	buffer  []float64 // Входной вектор, buffer[0] - самый новый отсчет
+	energy  float64   // Энергия входного вектора x^T x (скользящая сумма)
+}
+
+// NewLMSFilter создает адаптивный фильтр по классическому алгоритму LMS
+// numTaps: количество весов
+// mu: шаг адаптации (0 < mu < 2 / (numTaps * мощность входа) для сходимости)
+func NewLMSFilter(numTaps int, mu float64) (*LMSFilter, error) {
+	return newLMSFilter(StandardLMS, numTaps, mu, 0, 0)
+}
+
+// NewNLMSFilter создает адаптивный фильтр по нормированному алгоритму LMS
+// mu: нормированный шаг адаптации (0 < mu < 2)
+// eps: малая константа регуляризации, предотвращающая деление на ноль
+func NewNLMSFilter(numTaps int, mu, eps float64) (*LMSFilter, error) {
+	if mu <= 0 || mu >= 2 {
+		return nil, &InvalidParameterError{Param: "mu", Value: mu, Reason: "NLMS step size must be between 0 and 2"}
+	}
+	if eps < 0 {
+		return nil, &InvalidParameterError{Param: "eps", Value: eps, Reason: "regularization must be non-negative"}
+	}
+	return newLMSFilter(NormalizedLMS, numTaps, mu, 0, eps)
+}
+
+// NewLeakyLMSFilter создает адаптивный фильтр по алгоритму LMS с утечкой
+// leakage: коэффициент утечки (0 <= leakage, mu*leakage < 1), ограничивает рост весов
+func NewLeakyLMSFilter(numTaps int, mu, leakage float64) (*LMSFilter, error) {
+	if leakage < 0 || mu*leakage >= 1 {
+		return nil, &InvalidParameterError{Param: "leakage", Value: leakage, Reason: "leakage must satisfy 0 <= mu*leakage < 1"}
+	}
+	return newLMSFilter(LeakyLMS, numTaps, mu, leakage, 0)
+}
+
+// newLMSFilter создает фильтр семейства LMS с общей проверкой параметров
+func newLMSFilter(variant LMSVariant, numTaps int, mu, leakage, eps float64) (*LMSFilter, error) {
+	if numTaps < 1 {
+		return nil, &InvalidParameterError{Param: "numTaps", Value: float64(numTaps), Reason: "at least one tap is required"}
+	}
+	if mu <= 0 {
+		return nil, &InvalidParameterError{Param: "mu", Value: mu, Reason: "step size must be positive"}
+	}
+
+	return &LMSFilter{
+		variant: variant,
+		mu:      mu,
+		leakage: leakage,
+		eps:     eps,
+		weights: make([]float64, numTaps),
+		buffer:  make([]float64, numTaps),
+	}, nil
+}
+
+// Adapt обрабатывает один отсчет и обновляет веса
+func (f *LMSFilter) Adapt(input, desired float64) (float64, float64) {
+	// Сдвигаем входной вектор и обновляем его энергию
+	oldest := f.buffer[len(f.buffer)-1]
+	copy(f.buffer[1:], f.buffer[:len(f.buffer)-1])
+	f.buffer[0] = input
+	f.energy += input*input - oldest*oldest
+	if f.energy < 0 {
+		f.energy = 0 // Накопленная погрешность округления
+	}
+
+	output := dot(f.weights, f.buffer)
+	e := desired - output
+
+	step := f.mu
+	if f.variant == NormalizedLMS {
+		step = f.mu / (f.eps + f.energy)
+	}
+
+	decay := 1.0
+	if f.variant == LeakyLMS {
+		decay = 1 - f.mu*f.leakage
+	}
+
+	for i := range f.weights {
+		f.weights[i] = decay*f.weights[i] + step*e*f.buffer[i]
+	}
+
+	return output, e
+}
+
+// GetWeights возвращает копию текущих весов
+func (f *LMSFilter) GetWeights() []float64 {
+	return append([]float64{}, f.weights...)
+}
+
+// SetWeights устанавливает начальные веса фильтра
+func (f *LMSFilter) SetWeights(weights []float64) error {
+	if len(weights) != len(f.weights) {
+		return &InvalidParameterError{Param: "weights", Value: float64(len(weights)), Reason: "weight count must match tap count"}
+	}
+	copy(f.weights, weights)
+	return nil
+}
+
+// Reset сбрасывает веса и входной буфер
+func (f *LMSFilter) Reset() {
+	for i := range f.weights {
+		f.weights[i] = 0
+		f.buffer[i] = 0
+	}
+	f.energy = 0
+}
+
+// GetVariant возвращает вариант алгоритма
+func (f *LMSFilter) GetVariant() LMSVariant {
+	return f.variant
+}
+
+// RLSFilter реализует адаптивный фильтр по рекурсивному методу наименьших квадратов
+// с экспоненциальным забыванием. Сходится значительно быстрее LMS ценой O(N^2) операций на отсчет.
+type RLSFilter struct {
+	lambda float64 // Коэффициент забывания (0 < lambda <= 1)
+	delta  float64 // Начальная регуляризация P = I / delta
+
+	weights []float64   // Веса фильтра
+	buffer  []float64   // Входной вектор, buffer[0] - самый новый отсчет
+	p       [][]float64 // Обратная корреляционная матрица
+	px      []float64   // Рабочий вектор P*x
+}
+
+// NewRLSFilter создает RLS-фильтр
+// numTaps: количество весов
+// lambda: коэффициент забывания (обычно 0.99..1)
+// delta: начальная регуляризация (малое положительное число, например 0.01)
+func NewRLSFilter(numTaps int, lambda, delta float64) (*RLSFilter, error) {
+	if numTaps < 1 {
+		return nil, &InvalidParameterError{Param: "numTaps", Value: float64(numTaps), Reason: "at least one tap is required"}
+	}
+	if lambda <= 0 || lambda > 1 {
+		return nil, &InvalidParameterError{Param: "lambda", Value: lambda, Reason: "forgetting factor must be in (0, 1]"}
+	}
+	if delta <= 0 {
+		return nil, &InvalidParameterError{Param: "delta", Value: delta, Reason: "regularization must be positive"}
+	}
+
+	f := &RLSFilter{
+		lambda:  lambda,
+		delta:   delta,
+		weights: make([]float64, numTaps),
+		buffer:  make([]float64, numTaps),
+		p:       make([][]float64, numTaps),
+		px:      make([]float64, numTaps),
+	}
+	for i := range f.p {
+		f.p[i] = make([]float64, numTaps)
+	}
+	f.Reset()
+
+	return f, nil
+}
+
+// Adapt обрабатывает один отсчет и обновляет веса
+func (f *RLSFilter) Adapt(input, desired float64) (float64, float64) {
+	n := len(f.weights)
+	copy(f.buffer[1:], f.buffer[:n-1])
+	f.buffer[0] = input
+
+	output := dot(f.weights, f.buffer)
+	e := desired - output
+
+	// Вектор усиления: k = P*x / (lambda + x^T*P*x)
+	for i := 0; i < n; i++ {
+		f.px[i] = dot(f.p[i], f.buffer)
+	}
+	denom := f.lambda + dot(f.buffer, f.px)
+
+	for i := 0; i < n; i++ {
+		f.weights[i] += f.px[i] / denom * e
+	}
+
+	// P = (P - k * x^T * P) / lambda; матрица P симметрична, поэтому x^T*P = (P*x)^T
+	for i := 0; i < n; i++ {
+		ki := f.px[i] / denom
+		for j := 0; j < n; j++ {
+			f.p[i][j] = (f.p[i][j] - ki*f.px[j]) / f.lambda
+		}
+	}
+
+	return output, e
+}
+
+// GetWeights возвращает копию текущих весов
+func (f *RLSFilter) GetWeights() []float64 {
+	return append([]float64{}, f.weights...)
+}
+
+// Reset сбрасывает веса, входной буфер и обратную корреляционную матрицу
+func (f *RLSFilter) Reset() {
+	for i := range f.weights {
+		f.weights[i] = 0
+		f.buffer[i] = 0
+		for j := range f.p[i] {
+			f.p[i][j] = 0
+		}
+		f.p[i][i] = 1 / f.delta
+	}
+}
+
+// APAFilter реализует адаптивный фильтр по алгоритму аффинных проекций
+// Использует projectionOrder последних входных векторов, что ускоряет сходимость
+// при коррелированном входе (например, речь) по сравнению с NLMS.
+type APAFilter struct {
+	mu  float64 // Шаг адаптации (0 < mu < 2)
+	eps float64 // Регуляризация
+
+	weights []float64 // Веса фильтра
+	history []float64 // Входной буфер длины numTaps+order-1, history[0] - самый новый отсчет
+	desired []float64 // Последние желаемые значения, desired[0] - самое новое
+
+	gram [][]float64 // Рабочая матрица X^T*X + eps*I
+	errs []float64   // Рабочий вектор ошибок
+}
+
+// NewAPAFilter создает фильтр аффинных проекций
+// numTaps: количество весов
+// order: порядок проекции (количество используемых входных векторов)
+// mu: шаг адаптации (0 < mu < 2)
+// eps: регуляризация матрицы X^T*X
+func NewAPAFilter(numTaps, order int, mu, eps float64) (*APAFilter, error) {
+	if numTaps < 1 {
+		return nil, &InvalidParameterError{Param: "numTaps", Value: float64(numTaps), Reason: "at least one tap is required"}
+	}
+	if order < 1 {
+		return nil, &InvalidParameterError{Param: "order", Value: float64(order), Reason: "projection order must be at least 1"}
+	}
+	if mu <= 0 || mu >= 2 {
+		return nil, &InvalidParameterError{Param: "mu", Value: mu, Reason: "step size must be between 0 and 2"}
+	}
+	if eps <= 0 {
+		return nil, &InvalidParameterError{Param: "eps", Value: eps, Reason: "regularization must be positive"}
+	}
+
+	gram := make([][]float64, order)
+	for i := range gram {
+		gram[i] = make([]float64, order)
+	}
+
+	return &APAFilter{
+		mu:      mu,
+		eps:     eps,
+		weights: make([]float64, numTaps),
+		history: make([]float64, numTaps+order-1),
+		desired: make([]float64, order),
+		gram:    gram,
+		errs:    make([]float64, order),
+	}, nil
+}
+
+// Adapt обрабатывает один отсчет и обновляет веса
+func (f *APAFilter) Adapt(input, desired float64) (float64, float64) {
+	n := len(f.weights)
+	k := len(f.desired)
+
+	copy(f.history[1:], f.history[:len(f.history)-1])
+	f.history[0] = input
+	copy(f.desired[1:], f.desired[:k-1])
+	f.desired[0] = desired
+
+	// Входной вектор j-го момента времени: history[j : j+n]
+	column := func(j int) []float64 {
+		return f.history[j : j+n]
+	}
+
+	output := dot(f.weights, column(0))
+
+	// Вектор ошибок e = d - X^T*w и матрица X^T*X + eps*I
+	for i := 0; i < k; i++ {
+		f.errs[i] = f.desired[i] - dot(f.weights, column(i))
+		for j := i; j < k; j++ {
+			g := dot(column(i), column(j))
+			f.gram[i][j] = g
+			f.gram[j][i] = g
+		}
+		f.gram[i][i] += f.eps
+	}
+	e := f.errs[0]
+
+	// w += mu * X * (X^T*X + eps*I)^-1 * e
+	coeffs, ok := solveLinearSystem(f.gram, f.errs)
+	if ok {
+		for i := 0; i < k; i++ {
+			c := f.mu * coeffs[i]
+			col := column(i)
+			for j := range f.weights {
+				f.weights[j] += c * col[j]
+			}
+		}
+	}
+
+	return output, e
+}
+
+// GetWeights возвращает копию текущих весов
+func (f *APAFilter) GetWeights() []float64 {
+	return append([]float64{}, f.weights...)
+}
+
+// Reset сбрасывает веса и буферы
+func (f *APAFilter) Reset() {
+	for i := range f.weights {
+		f.weights[i] = 0
+	}
+	for i := range f.history {
+		f.history[i] = 0
+	}
+	for i := range f.desired {
+		f.desired[i] = 0
+	}
+}
+
+// dot вычисляет скалярное произведение векторов одинаковой длины
+func dot(a, b []float64) float64 {
+	var sum float64
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+// solveLinearSystem решает систему A*x = b методом Гаусса с выбором главного элемента
+// Исходные A и b не изменяются. Возвращает false для вырожденной матрицы.
+func solveLinearSystem(a [][]float64, b []float64) ([]float64, bool) {
+	n := len(b)
+	m := make([][]float64, n)
+	for i := range m {
+		m[i] = make([]float64, n+1)
+		copy(m[i], a[i])
+		m[i][n] = b[i]
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(m[row][col]) > math.Abs(m[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(m[pivot][col]) < 1e-300 {
+			return nil, false
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+
+		for row := col + 1; row < n; row++ {
+			factor := m[row][col] / m[col][col]
+			for j := col; j <= n; j++ {
+				m[row][j] -= factor * m[col][j]
+			}
+		}
+	}
+
+	x := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		sum := m[i][n]
+		for j := i + 1; j < n; j++ {
+			sum -= m[i][j] * x[j]
+		}
+		x[i] = sum / m[i][i]
+	}
+
+	return x, true
+}
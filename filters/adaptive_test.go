@@ -0,0 +1,168 @@
+package filters
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// identifySystem прогоняет адаптивный фильтр на задаче идентификации неизвестной КИХ-системы
+// и возвращает максимальное отклонение весов от истинных коэффициентов
+func identifySystem(f AdaptiveFilter, system []float64, samples int, noise float64, colored bool) float64 {
+	rng := rand.New(rand.NewSource(42))
+	plant := NewFIRFilter(append([]float64{}, system...))
+
+	var prev float64
+	for n := 0; n < samples; n++ {
+		x := rng.NormFloat64()
+		if colored {
+			// Коррелированный вход (AR(1)) затрудняет сходимость LMS
+			x = 0.9*prev + x
+			prev = x
+		}
+		d := plant.Tick(x) + noise*rng.NormFloat64()
+		f.Adapt(x, d)
+	}
+
+	weights := f.GetWeights()
+	var maxErr float64
+	for i, w := range weights {
+		want := 0.0
+		if i < len(system) {
+			want = system[i]
+		}
+		maxErr = math.Max(maxErr, math.Abs(w-want))
+	}
+	return maxErr
+}
+
+// TestAdaptiveFiltersConvergence проверяет сходимость всех алгоритмов к известной системе
+func TestAdaptiveFiltersConvergence(t *testing.T) {
+	system := []float64{0.5, -0.3, 0.2, 0.1, -0.05}
+
+	newFilters := map[string]func() (AdaptiveFilter, error){
+		"LMS":  func() (AdaptiveFilter, error) { return NewLMSFilter(8, 0.01) },
+		"NLMS": func() (AdaptiveFilter, error) { return NewNLMSFilter(8, 0.5, 1e-6) },
+		"RLS":  func() (AdaptiveFilter, error) { return NewRLSFilter(8, 0.999, 0.01) },
+		"APA":  func() (AdaptiveFilter, error) { return NewAPAFilter(8, 4, 0.5, 1e-4) },
+	}
+
+	for name, create := range newFilters {
+		t.Run(name, func(t *testing.T) {
+			f, err := create()
+			if err != nil {
+				t.Fatalf("failed to create filter: %v", err)
+			}
+			if e := identifySystem(f, system, 5000, 0.001, false); e > 0.01 {
+				t.Errorf("weights did not converge, max error %g", e)
+			}
+
+			f.Reset()
+			for _, w := range f.GetWeights() {
+				if w != 0 {
+					t.Fatal("weights should be zero after reset")
+				}
+			}
+		})
+	}
+}
+
+// TestFastConvergenceOnColoredInput проверяет преимущество RLS и APA на коррелированном входе
+func TestFastConvergenceOnColoredInput(t *testing.T) {
+	system := []float64{0.8, 0.4, -0.2, 0.1}
+
+	lms, _ := NewNLMSFilter(4, 0.1, 1e-6)
+	rls, _ := NewRLSFilter(4, 0.999, 0.01)
+	apa, _ := NewAPAFilter(4, 3, 0.5, 1e-4)
+
+	lmsErr := identifySystem(lms, system, 300, 0, true)
+	rlsErr := identifySystem(rls, system, 300, 0, true)
+	apaErr := identifySystem(apa, system, 300, 0, true)
+
+	if rlsErr > 1e-3 {
+		t.Errorf("RLS should converge within 300 samples, max error %g", rlsErr)
+	}
+	if apaErr >= lmsErr {
+		t.Errorf("APA (%g) should converge faster than NLMS (%g) on colored input", apaErr, lmsErr)
+	}
+}
+
+// TestLeakyLMS проверяет ограничение весов утечкой
+func TestLeakyLMS(t *testing.T) {
+	system := []float64{1.0, 0.5}
+
+	plain, _ := NewLMSFilter(2, 0.01)
+	leaky, err := NewLeakyLMSFilter(2, 0.01, 0.1)
+	if err != nil {
+		t.Fatalf("failed to create leaky LMS: %v", err)
+	}
+
+	identifySystem(plain, system, 5000, 0, false)
+	identifySystem(leaky, system, 5000, 0, false)
+
+	// Утечка смещает веса к нулю: ||w_leaky|| < ||w_lms||, но фильтр по-прежнему адаптируется
+	norm := func(w []float64) float64 { return math.Sqrt(dot(w, w)) }
+	pw, lw := norm(plain.GetWeights()), norm(leaky.GetWeights())
+	if lw >= pw {
+		t.Errorf("leaky weights norm %f should be smaller than plain LMS %f", lw, pw)
+	}
+	if lw < 0.8*pw {
+		t.Errorf("leaky weights norm %f is too strongly biased", lw)
+	}
+
+	if _, err := NewLeakyLMSFilter(2, 0.5, 3); err == nil {
+		t.Error("expected error for mu*leakage >= 1")
+	}
+}
+
+// TestAdaptiveEchoCancellation проверяет подавление эха при наличии ближнего сигнала
+func TestAdaptiveEchoCancellation(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	echoPath := []float64{0, 0, 0.6, 0.3, -0.1, 0.05}
+	echo := NewFIRFilter(echoPath)
+
+	canceller, err := NewNLMSFilter(8, 0.3, 1e-6)
+	if err != nil {
+		t.Fatalf("failed to create canceller: %v", err)
+	}
+
+	var echoPower, residualPower float64
+	for n := 0; n < 20000; n++ {
+		farEnd := rng.NormFloat64()
+		e := echo.Tick(farEnd)
+		_, residual := canceller.Adapt(farEnd, e)
+		if n >= 15000 {
+			echoPower += e * e
+			residualPower += residual * residual
+		}
+	}
+
+	erle := 10 * math.Log10(echoPower/residualPower)
+	if erle < 40 {
+		t.Errorf("echo return loss enhancement %.1f dB, want at least 40 dB", erle)
+	}
+}
+
+// TestAdaptiveFilterParameters проверяет обработку неверных параметров
+func TestAdaptiveFilterParameters(t *testing.T) {
+	if _, err := NewLMSFilter(0, 0.1); err == nil {
+		t.Error("expected error for zero taps")
+	}
+	if _, err := NewLMSFilter(4, 0); err == nil {
+		t.Error("expected error for zero step size")
+	}
+	if _, err := NewNLMSFilter(4, 2.5, 1e-6); err == nil {
+		t.Error("expected error for NLMS step size above 2")
+	}
+	if _, err := NewRLSFilter(4, 1.5, 0.01); err == nil {
+		t.Error("expected error for forgetting factor above 1")
+	}
+	if _, err := NewAPAFilter(4, 0, 0.5, 1e-4); err == nil {
+		t.Error("expected error for zero projection order")
+	}
+
+	f, _ := NewLMSFilter(3, 0.1)
+	if err := f.SetWeights([]float64{1, 2}); err == nil {
+		t.Error("expected error for wrong weight count")
+	}
+}
@@ -0,0 +1,333 @@
+package filters
+
+import (
+	"math"
+)
+
+// CICDecimator представляет собой каскадный интегрально-гребенчатый (CIC) фильтр-дециматор
+// Структура: order интеграторов на входной частоте, прореживание в ratio раз,
+// затем order гребенчатых звеньев y[m] = x[m] - x[m-diffDelay] на выходной частоте.
+// Интеграторы работают в целочисленной арифметике с переполнением по модулю 2^64:
+// при достаточной разрядности выхода переполнения в интеграторах компенсируются гребенками.
+type CICDecimator struct {
+	order     int // Порядок фильтра (количество интеграторов и гребенок)
+	ratio     int // Коэффициент прореживания R
+	diffDelay int // Дифференциальная задержка гребенки M
+
+	integrators []int64   // Состояния интеграторов
+	combs       [][]int64 // Линии задержки гребенчатых звеньев
+	combPos     int       // Текущая позиция в линиях задержки гребенок
+	phase       int       // Счетчик отсчетов до очередного выходного отсчета
+}
+
+// NewCICDecimator создает новый CIC-дециматор
+// order: порядок фильтра N (>= 1)
+// ratio: коэффициент прореживания R (>= 1)
+// diffDelay: дифференциальная задержка M (обычно 1 или 2)
+func NewCICDecimator(order, ratio, diffDelay int) (*CICDecimator, error) {
+	if err := validateCICParams(order, ratio, diffDelay); err != nil {
+		return nil, err
+	}
+
+	combs := make([][]int64, order)
+	for i := range combs {
+		combs[i] = make([]int64, diffDelay)
+	}
+
+	return &CICDecimator{
+		order:       order,
+		ratio:       ratio,
+		diffDelay:   diffDelay,
+		integrators: make([]int64, order),
+		combs:       combs,
+	}, nil
+}
+
+// Tick обрабатывает один входной отсчет
+// Возвращает выходной отсчет и true, если на данном такте сформирован выходной отсчет
+func (c *CICDecimator) Tick(input int64) (int64, bool) {
+	// Интеграторы на входной частоте (переполнение допустимо)
+	v := input
+	for i := range c.integrators {
+		c.integrators[i] += v
+		v = c.integrators[i]
+	}
+
+	c.phase++
+	if c.phase < c.ratio {
+		return 0, false
+	}
+	c.phase = 0
+
+	// Гребенчатые звенья на выходной частоте
+	for i := range c.combs {
+		delayed := c.combs[i][c.combPos]
+		c.combs[i][c.combPos] = v
+		v -= delayed
+	}
+	c.combPos = (c.combPos + 1) % c.diffDelay
+
+	return v, true
+}
+
+// Process обрабатывает срез входных отсчетов и возвращает прореженный выход
+func (c *CICDecimator) Process(input []int64) []int64 {
+	output := make([]int64, 0, len(input)/c.ratio+1)
+	for _, x := range input {
+		if y, ok := c.Tick(x); ok {
+			output = append(output, y)
+		}
+	}
+	return output
+}
+
+// Reset сбрасывает состояние фильтра
+func (c *CICDecimator) Reset() {
+	for i := range c.integrators {
+		c.integrators[i] = 0
+	}
+	for i := range c.combs {
+		for j := range c.combs[i] {
+			c.combs[i][j] = 0
+		}
+	}
+	c.combPos = 0
+	c.phase = 0
+}
+
+// GetGain возвращает коэффициент усиления на нулевой частоте: (R*M)^N
+func (c *CICDecimator) GetGain() float64 {
+	return cicGain(c.order, c.ratio, c.diffDelay)
+}
+
+// GetBitGrowth возвращает прирост разрядности: ceil(N*log2(R*M))
+func (c *CICDecimator) GetBitGrowth() int {
+	return cicBitGrowth(c.order, c.ratio, c.diffDelay)
+}
+
+// GetRatio возвращает коэффициент прореживания
+func (c *CICDecimator) GetRatio() int {
+	return c.ratio
+}
+
+// CICInterpolator представляет собой CIC-фильтр-интерполятор
+// Структура: order гребенчатых звеньев на входной частоте, вставка ratio-1 нулей,
+// затем order интеграторов на выходной частоте.
+type CICInterpolator struct {
+	order     int // Порядок фильтра
+	ratio     int // Коэффициент интерполяции R
+	diffDelay int // Дифференциальная задержка гребенки M
+
+	integrators []int64   // Состояния интеграторов
+	combs       [][]int64 // Линии задержки гребенчатых звеньев
+	combPos     int       // Текущая позиция в линиях задержки гребенок
+}
+
+// NewCICInterpolator создает новый CIC-интерполятор
+func NewCICInterpolator(order, ratio, diffDelay int) (*CICInterpolator, error) {
+	if err := validateCICParams(order, ratio, diffDelay); err != nil {
+		return nil, err
+	}
+
+	combs := make([][]int64, order)
+	for i := range combs {
+		combs[i] = make([]int64, diffDelay)
+	}
+
+	return &CICInterpolator{
+		order:       order,
+		ratio:       ratio,
+		diffDelay:   diffDelay,
+		integrators: make([]int64, order),
+		combs:       combs,
+	}, nil
+}
+
+// Tick обрабатывает один входной отсчет и возвращает ratio выходных отсчетов
+func (c *CICInterpolator) Tick(input int64) []int64 {
+	// Гребенчатые звенья на входной частоте
+	v := input
+	for i := range c.combs {
+		delayed := c.combs[i][c.combPos]
+		c.combs[i][c.combPos] = v
+		v -= delayed
+	}
+	c.combPos = (c.combPos + 1) % c.diffDelay
+
+	// Вставка нулей и интеграторы на выходной частоте
+	output := make([]int64, c.ratio)
+	for j := 0; j < c.ratio; j++ {
+		x := int64(0)
+		if j == 0 {
+			x = v
+		}
+		for i := range c.integrators {
+			c.integrators[i] += x
+			x = c.integrators[i]
+		}
+		output[j] = x
+	}
+
+	return output
+}
+
+// Process обрабатывает срез входных отсчетов и возвращает интерполированный выход
+func (c *CICInterpolator) Process(input []int64) []int64 {
+	output := make([]int64, 0, len(input)*c.ratio)
+	for _, x := range input {
+		output = append(output, c.Tick(x)...)
+	}
+	return output
+}
+
+// Reset сбрасывает состояние фильтра
+func (c *CICInterpolator) Reset() {
+	for i := range c.integrators {
+		c.integrators[i] = 0
+	}
+	for i := range c.combs {
+		for j := range c.combs[i] {
+			c.combs[i][j] = 0
+		}
+	}
+	c.combPos = 0
+}
+
+// GetGain возвращает коэффициент усиления на нулевой частоте: (R*M)^N / R
+func (c *CICInterpolator) GetGain() float64 {
+	return cicGain(c.order, c.ratio, c.diffDelay) / float64(c.ratio)
+}
+
+// GetBitGrowth возвращает прирост разрядности: ceil(log2((R*M)^N / R))
+func (c *CICInterpolator) GetBitGrowth() int {
+	return int(math.Ceil(math.Log2(c.GetGain()) - 1e-9))
+}
+
+// GetRatio возвращает коэффициент интерполяции
+func (c *CICInterpolator) GetRatio() int {
+	return c.ratio
+}
+
+// CICMagnitudeResponse возвращает нормированную АЧХ CIC-фильтра
+// freq: частота, нормированная к низкой (выходной для дециматора) частоте дискретизации (0..0.5)
+// |H(f)| = |sin(pi*M*f) / (R*M*sin(pi*f/R))|^N
+func CICMagnitudeResponse(order, ratio, diffDelay int, freq float64) float64 {
+	m := float64(diffDelay)
+	r := float64(ratio)
+
+	num := math.Sin(math.Pi * m * freq)
+	den := r * m * math.Sin(math.Pi*freq/r)
+	if math.Abs(den) < 1e-15 {
+		return 1.0
+	}
+
+	return math.Pow(math.Abs(num/den), float64(order))
+}
+
+// DesignCICCompensator рассчитывает коэффициенты КИХ-фильтра компенсации спада АЧХ CIC-фильтра
+// Фильтр работает на низкой частоте дискретизации и имеет АЧХ 1/|H_cic(f)| в полосе пропускания
+// numTaps: число коэффициентов (приводится к нечетному)
+// passband: граница полосы пропускания (нормирована к низкой частоте дискретизации, 0 < passband < stopband)
+// stopband: граница полосы задерживания (stopband <= 0.5)
+func DesignCICCompensator(order, ratio, diffDelay, numTaps int, passband, stopband float64) ([]float64, error) {
+	if err := validateCICParams(order, ratio, diffDelay); err != nil {
+		return nil, err
+	}
+	if numTaps < 3 {
+		return nil, &InvalidParameterError{Param: "numTaps", Value: float64(numTaps), Reason: "at least 3 taps are required"}
+	}
+	if passband <= 0 || passband >= 0.5 {
+		return nil, &InvalidParameterError{Param: "passband", Value: passband, Reason: "passband edge must be between 0 and 0.5"}
+	}
+	if stopband <= passband || stopband > 0.5 {
+		return nil, &InvalidParameterError{Param: "stopband", Value: stopband, Reason: "stopband edge must be between passband and 0.5"}
+	}
+
+	// Нечетная длина дает фильтр типа I с линейной фазой
+	if numTaps%2 == 0 {
+		numTaps++
+	}
+	center := numTaps / 2
+
+	// Желаемая АЧХ: обратная к CIC в полосе пропускания,
+	// линейный спад в переходной полосе, ноль в полосе задерживания
+	desired := func(f float64) float64 {
+		if f <= passband {
+			return 1.0 / CICMagnitudeResponse(order, ratio, diffDelay, f)
+		}
+		if f >= stopband {
+			return 0
+		}
+		edge := 1.0 / CICMagnitudeResponse(order, ratio, diffDelay, passband)
+		return edge * (stopband - f) / (stopband - passband)
+	}
+
+	// Импульсная характеристика как численный интеграл обратного преобразования Фурье:
+	// h[n] = 2 * integral_0^0.5 D(f) cos(2*pi*f*(n-center)) df
+	const gridSize = 4096
+	df := 0.5 / gridSize
+	coeffs := make([]float64, numTaps)
+	for i := 0; i <= gridSize; i++ {
+		f := float64(i) * df
+		weight := 2 * df
+		if i == 0 || i == gridSize {
+			weight = df // Метод трапеций
+		}
+		d := desired(f) * weight
+		if d == 0 {
+			continue
+		}
+		for n := 0; n < numTaps; n++ {
+			coeffs[n] += d * math.Cos(2*math.Pi*f*float64(n-center))
+		}
+	}
+
+	// Окно Хэмминга для снижения эффекта Гиббса
+	for n := 0; n < numTaps; n++ {
+		coeffs[n] *= 0.54 - 0.46*math.Cos(2*math.Pi*float64(n)/float64(numTaps-1))
+	}
+
+	// Нормировка на единичное усиление на нулевой частоте
+	var sum float64
+	for _, h := range coeffs {
+		sum += h
+	}
+	for n := range coeffs {
+		coeffs[n] /= sum
+	}
+
+	return coeffs, nil
+}
+
+// NewCICCompensationFilter создает КИХ-фильтр компенсации спада АЧХ CIC-фильтра
+func NewCICCompensationFilter(order, ratio, diffDelay, numTaps int, passband, stopband float64) (*FIRFilter, error) {
+	coeffs, err := DesignCICCompensator(order, ratio, diffDelay, numTaps, passband, stopband)
+	if err != nil {
+		return nil, err
+	}
+	return NewFIRFilter(coeffs), nil
+}
+
+// validateCICParams проверяет параметры CIC-фильтра
+func validateCICParams(order, ratio, diffDelay int) error {
+	if order < 1 {
+		return &InvalidParameterError{Param: "order", Value: float64(order), Reason: "order must be at least 1"}
+	}
+	if ratio < 1 {
+		return &InvalidParameterError{Param: "ratio", Value: float64(ratio), Reason: "ratio must be at least 1"}
+	}
+	if diffDelay < 1 {
+		return &InvalidParameterError{Param: "diffDelay", Value: float64(diffDelay), Reason: "differential delay must be at least 1"}
+	}
+	return nil
+}
+
+// cicGain вычисляет коэффициент усиления CIC-фильтра (R*M)^N
+func cicGain(order, ratio, diffDelay int) float64 {
+	return math.Pow(float64(ratio*diffDelay), float64(order))
+}
+
+// cicBitGrowth вычисляет прирост разрядности CIC-дециматора
+func cicBitGrowth(order, ratio, diffDelay int) int {
+	return int(math.Ceil(float64(order)*math.Log2(float64(ratio*diffDelay)) - 1e-9))
+}
@@ -0,0 +1,263 @@
+package filters
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// cicImpulseResponse вычисляет импульсную характеристику CIC-фильтра на высокой частоте
+// как N-кратную свертку прямоугольного окна длины R*M
+func cicImpulseResponse(order, ratio, diffDelay int) []int64 {
+	box := make([]int64, ratio*diffDelay)
+	for i := range box {
+		box[i] = 1
+	}
+
+	h := []int64{1}
+	for s := 0; s < order; s++ {
+		next := make([]int64, len(h)+len(box)-1)
+		for i, a := range h {
+			for j, b := range box {
+				next[i+j] += a * b
+			}
+		}
+		h = next
+	}
+	return h
+}
+
+// convolveInt выполняет прямую свертку целочисленных последовательностей
+func convolveInt(x, h []int64) []int64 {
+	y := make([]int64, len(x))
+	for n := range x {
+		for k, hk := range h {
+			if n-k < 0 {
+				break
+			}
+			y[n] += hk * x[n-k]
+		}
+	}
+	return y
+}
+
+// TestCICDecimatorMatchesDirectForm сравнивает дециматор с прямой сверткой и прореживанием
+func TestCICDecimatorMatchesDirectForm(t *testing.T) {
+	configs := []struct{ order, ratio, diffDelay int }{
+		{1, 4, 1},
+		{3, 8, 1},
+		{4, 5, 2},
+	}
+
+	input := make([]int64, 200)
+	for i := range input {
+		input[i] = int64((i*37)%23 - 11)
+	}
+
+	for _, cfg := range configs {
+		cic, err := NewCICDecimator(cfg.order, cfg.ratio, cfg.diffDelay)
+		if err != nil {
+			t.Fatalf("failed to create decimator: %v", err)
+		}
+
+		full := convolveInt(input, cicImpulseResponse(cfg.order, cfg.ratio, cfg.diffDelay))
+		output := cic.Process(input)
+
+		if len(output) != len(input)/cfg.ratio {
+			t.Fatalf("N=%d R=%d M=%d: expected %d outputs, got %d",
+				cfg.order, cfg.ratio, cfg.diffDelay, len(input)/cfg.ratio, len(output))
+		}
+		for m, y := range output {
+			want := full[(m+1)*cfg.ratio-1]
+			if y != want {
+				t.Errorf("N=%d R=%d M=%d: output[%d] = %d, want %d",
+					cfg.order, cfg.ratio, cfg.diffDelay, m, y, want)
+				break
+			}
+		}
+	}
+}
+
+// TestCICDecimatorWraparound проверяет корректность выхода при переполнении интеграторов
+func TestCICDecimatorWraparound(t *testing.T) {
+	cic, err := NewCICDecimator(3, 4, 1)
+	if err != nil {
+		t.Fatalf("failed to create decimator: %v", err)
+	}
+
+	// Интеграторы 3-го порядка переполняются уже через несколько сотен отсчетов
+	const dc = int64(1) << 40
+	want := dc * int64(cic.GetGain())
+
+	var last int64
+	for i := 0; i < 100000; i++ {
+		if y, ok := cic.Tick(dc); ok {
+			last = y
+		}
+	}
+
+	if last != want {
+		t.Errorf("expected steady-state output %d, got %d", want, last)
+	}
+}
+
+// TestCICInterpolatorMatchesDirectForm сравнивает интерполятор со вставкой нулей и сверткой
+func TestCICInterpolatorMatchesDirectForm(t *testing.T) {
+	order, ratio, diffDelay := 3, 4, 1
+	cic, err := NewCICInterpolator(order, ratio, diffDelay)
+	if err != nil {
+		t.Fatalf("failed to create interpolator: %v", err)
+	}
+
+	input := []int64{5, -3, 7, 0, 2, 9, -8, 1, 4, -6}
+	upsampled := make([]int64, len(input)*ratio)
+	for i, x := range input {
+		upsampled[i*ratio] = x
+	}
+	want := convolveInt(upsampled, cicImpulseResponse(order, ratio, diffDelay))
+
+	output := cic.Process(input)
+	if len(output) != len(want) {
+		t.Fatalf("expected %d outputs, got %d", len(want), len(output))
+	}
+	for i := range output {
+		if output[i] != want[i] {
+			t.Fatalf("output[%d] = %d, want %d", i, output[i], want[i])
+		}
+	}
+
+	if math.Abs(cic.GetGain()-16) > 1e-12 {
+		t.Errorf("expected interpolator gain 16, got %f", cic.GetGain())
+	}
+}
+
+// TestCICParameters проверяет усиление, прирост разрядности и обработку ошибок
+func TestCICParameters(t *testing.T) {
+	cic, err := NewCICDecimator(4, 16, 1)
+	if err != nil {
+		t.Fatalf("failed to create decimator: %v", err)
+	}
+	if cic.GetGain() != 65536 {
+		t.Errorf("expected gain 65536, got %f", cic.GetGain())
+	}
+	if cic.GetBitGrowth() != 16 {
+		t.Errorf("expected bit growth 16, got %d", cic.GetBitGrowth())
+	}
+
+	if _, err := NewCICDecimator(0, 4, 1); err == nil {
+		t.Error("expected error for zero order")
+	}
+	if _, err := NewCICDecimator(3, 0, 1); err == nil {
+		t.Error("expected error for zero ratio")
+	}
+	if _, err := NewCICInterpolator(3, 4, 0); err == nil {
+		t.Error("expected error for zero differential delay")
+	}
+
+	// Сброс возвращает фильтр в исходное состояние
+	first := cic.Process([]int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	cic.Reset()
+	second := cic.Process([]int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	if first[0] != second[0] {
+		t.Errorf("output after reset differs: %d vs %d", first[0], second[0])
+	}
+}
+
+// TestCICCompensator проверяет выравнивание АЧХ в полосе пропускания
+func TestCICCompensator(t *testing.T) {
+	order, ratio, diffDelay := 4, 16, 1
+	passband, stopband := 0.2, 0.3
+
+	fir, err := NewCICCompensationFilter(order, ratio, diffDelay, 63, passband, stopband)
+	if err != nil {
+		t.Fatalf("failed to design compensator: %v", err)
+	}
+	coeffs := fir.GetCoefficients()
+
+	firResponse := func(f float64) float64 {
+		var h complex128
+		for n, c := range coeffs {
+			h += complex(c, 0) * cmplx.Exp(complex(0, -2*math.Pi*f*float64(n)))
+		}
+		return cmplx.Abs(h)
+	}
+
+	// Без компенсации спад на границе полосы пропускания превышает 2 дБ
+	droop := -20 * math.Log10(CICMagnitudeResponse(order, ratio, diffDelay, passband))
+	if droop < 2 {
+		t.Fatalf("unexpectedly small CIC droop: %.2f dB", droop)
+	}
+
+	for f := 0.0; f <= passband; f += 0.01 {
+		total := CICMagnitudeResponse(order, ratio, diffDelay, f) * firResponse(f)
+		if dB := 20 * math.Log10(total); math.Abs(dB) > 0.2 {
+			t.Errorf("compensated response at f=%.2f is %.3f dB, want within ±0.2 dB", f, dB)
+		}
+	}
+
+	// Симметрия коэффициентов (линейная фаза)
+	for i := 0; i < len(coeffs)/2; i++ {
+		if math.Abs(coeffs[i]-coeffs[len(coeffs)-1-i]) > 1e-12 {
+			t.Errorf("coefficients are not symmetric at %d", i)
+		}
+	}
+
+	if _, err := DesignCICCompensator(order, ratio, diffDelay, 31, 0.3, 0.2); err == nil {
+		t.Error("expected error for stopband below passband")
+	}
+}
+
+// TestCICMagnitudeResponseDiffDelay сверяет формулу АЧХ с ДВПФ импульсной характеристики
+// для дифференциальной задержки M > 1 и проверяет непрерывность у нуля частоты
+func TestCICMagnitudeResponseDiffDelay(t *testing.T) {
+	order, ratio := 3, 8
+	for _, diffDelay := range []int{1, 2, 3} {
+		h := cicImpulseResponse(order, ratio, diffDelay)
+		gain := cicGain(order, ratio, diffDelay)
+
+		for _, f := range []float64{0, 1e-6, 0.01, 0.1, 0.2, 0.3, 0.45} {
+			var sum complex128
+			for n, hn := range h {
+				sum += complex(float64(hn), 0) * cmplx.Exp(complex(0, -2*math.Pi*f/float64(ratio)*float64(n)))
+			}
+			want := cmplx.Abs(sum) / gain
+			if got := CICMagnitudeResponse(order, ratio, diffDelay, f); math.Abs(got-want) > 1e-9 {
+				t.Errorf("M=%d f=%g: response %.9f, want %.9f", diffDelay, f, got, want)
+			}
+		}
+
+		if got := CICMagnitudeResponse(order, ratio, diffDelay, 0); got != 1 {
+			t.Errorf("M=%d: DC response %v, want 1", diffDelay, got)
+		}
+		prev := 1.0
+		for f := 1e-4; f < 0.05; f += 1e-3 {
+			got := CICMagnitudeResponse(order, ratio, diffDelay, f)
+			if math.Abs(got-prev) > 0.01 {
+				t.Fatalf("M=%d: response jumps from %v to %v near f=%g", diffDelay, prev, got, f)
+			}
+			prev = got
+		}
+	}
+}
+
+// TestCICCompensatorDiffDelay проверяет компенсатор при M = 2
+func TestCICCompensatorDiffDelay(t *testing.T) {
+	order, ratio, diffDelay := 3, 16, 2
+	passband, stopband := 0.1, 0.2
+
+	coeffs, err := DesignCICCompensator(order, ratio, diffDelay, 63, passband, stopband)
+	if err != nil {
+		t.Fatalf("failed to design compensator: %v", err)
+	}
+	// Край полосы пропускания сглажен окном проектирования и не проверяется
+	for f := 0.0; f < passband-1e-9; f += 0.01 {
+		var h complex128
+		for n, c := range coeffs {
+			h += complex(c, 0) * cmplx.Exp(complex(0, -2*math.Pi*f*float64(n)))
+		}
+		total := CICMagnitudeResponse(order, ratio, diffDelay, f) * cmplx.Abs(h)
+		if dB := 20 * math.Log10(total); math.Abs(dB) > 0.2 {
+			t.Errorf("compensated response at f=%.2f is %.3f dB, want within ±0.2 dB", f, dB)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package filters
+
+import (
+	"math/cmplx"
+
+	"github.com/Alexxtn105/dsp/fft"
+)
+
+// PartitionedFDAF реализует адаптивный фильтр в частотной области с разбиением на блоки
+// (partitioned-block frequency-domain adaptive filter, PBFDAF, метод перекрытия с сохранением).
+// Импульсная характеристика длины partitions*blockSize делится на partitions частей,
+// каждая из которых адаптируется в частотной области по блочному алгоритму LMS
+// с нормировкой шага по мощности входа в каждом бине БПФ.
+// При partitions = 1 получается классический блочный LMS в частотной области (FDAF).
+// Адаптивная фильтрация в поддиапазонах (subband) не реализована.
+type PartitionedFDAF struct {
+	blockSize   int     // Размер блока B
+	partitions  int     // Количество разбиений P
+	mu          float64 // Шаг адаптации (0 < mu < 2)
+	beta        float64 // Коэффициент сглаживания оценки мощности (0 <= beta < 1)
+	eps         float64 // Регуляризация нормировки
+	constrained bool    // Применять ограничение градиента (обнуление циклической части)
+
+	weights  [][]complex128 // Частотные веса разбиений W_p (длина 2B)
+	spectra  [][]complex128 // Линия задержки спектров входа X_{k-p}
+	specPos  int            // Позиция самого нового спектра в линии задержки
+	power    []float64      // Сглаженная мощность входа по бинам
+	betaPow  float64        // beta^n для коррекции смещения оценки мощности
+	prevHalf []float64      // Предыдущий входной блок (первая половина окна БПФ)
+}
+
+// NewPartitionedFDAF создает адаптивный фильтр в частотной области
+// blockSize: размер блока B (степень двойки); задержка обработки равна B отсчетам
+// partitions: количество разбиений P; длина фильтра равна P*B
+// mu: нормированный шаг адаптации (0 < mu < 2)
+func NewPartitionedFDAF(blockSize, partitions int, mu float64) (*PartitionedFDAF, error) {
+	if !fft.IsPowerOfTwo(blockSize) {
+		return nil, &InvalidParameterError{Param: "blockSize", Value: float64(blockSize), Reason: "block size must be a power of 2"}
+	}
+	if partitions < 1 {
+		return nil, &InvalidParameterError{Param: "partitions", Value: float64(partitions), Reason: "at least one partition is required"}
+	}
+	if mu <= 0 || mu >= 2 {
+		return nil, &InvalidParameterError{Param: "mu", Value: mu, Reason: "step size must be between 0 and 2"}
+	}
+
+	f := &PartitionedFDAF{
+		blockSize:   blockSize,
+		partitions:  partitions,
+		mu:          mu,
+		beta:        0.9,
+		eps:         1e-6,
+		constrained: true,
+		betaPow:     1,
+		weights:     make([][]complex128, partitions),
+		spectra:     make([][]complex128, partitions),
+		power:       make([]float64, 2*blockSize),
+		prevHalf:    make([]float64, blockSize),
+	}
+	for p := 0; p < partitions; p++ {
+		f.weights[p] = make([]complex128, 2*blockSize)
+		f.spectra[p] = make([]complex128, 2*blockSize)
+	}
+
+	return f, nil
+}
+
+// NewFrequencyDomainLMS создает блочный LMS-фильтр в частотной области длиной blockSize
+func NewFrequencyDomainLMS(blockSize int, mu float64) (*PartitionedFDAF, error) {
+	return NewPartitionedFDAF(blockSize, 1, mu)
+}
+
+// SetPowerSmoothing устанавливает коэффициент сглаживания оценки мощности по бинам (0 <= beta < 1)
+func (f *PartitionedFDAF) SetPowerSmoothing(beta float64) error {
+	if beta < 0 || beta >= 1 {
+		return &InvalidParameterError{Param: "beta", Value: beta, Reason: "smoothing factor must be in [0, 1)"}
+	}
+	f.beta = beta
+	return nil
+}
+
+// SetConstrained включает или отключает ограничение градиента
+// Без ограничения вычисления дешевле на два БПФ на разбиение, но сходимость хуже.
+func (f *PartitionedFDAF) SetConstrained(constrained bool) {
+	f.constrained = constrained
+}
+
+// ProcessBlock обрабатывает блок из blockSize отсчетов входа и желаемого сигнала
+// Возвращает выход фильтра и ошибку (desired - output) для блока
+func (f *PartitionedFDAF) ProcessBlock(input, desired []float64) (output, errSignal []float64, err error) {
+	b := f.blockSize
+	if len(input) != b || len(desired) != b {
+		return nil, nil, &InvalidParameterError{Param: "input", Value: float64(len(input)), Reason: "block length must equal block size"}
+	}
+
+	// Спектр окна из предыдущего и текущего блоков
+	frame := make([]complex128, 2*b)
+	for i := 0; i < b; i++ {
+		frame[i] = complex(f.prevHalf[i], 0)
+		frame[b+i] = complex(input[i], 0)
+	}
+	copy(f.prevHalf, input)
+
+	f.specPos = (f.specPos + f.partitions - 1) % f.partitions
+	f.spectra[f.specPos] = fft.FFT(frame)
+	newest := f.spectra[f.specPos]
+
+	// Выход: Y = sum_p W_p * X_{k-p}; последние B отсчетов ОБПФ (перекрытие с сохранением)
+	acc := make([]complex128, 2*b)
+	for p := 0; p < f.partitions; p++ {
+		x := f.spectra[(f.specPos+p)%f.partitions]
+		w := f.weights[p]
+		for k := range acc {
+			acc[k] += w[k] * x[k]
+		}
+	}
+	y := fft.IFFT(acc)
+
+	output = make([]float64, b)
+	errSignal = make([]float64, b)
+	errFrame := make([]complex128, 2*b)
+	for i := 0; i < b; i++ {
+		output[i] = real(y[b+i])
+		errSignal[i] = desired[i] - output[i]
+		errFrame[b+i] = complex(errSignal[i], 0)
+	}
+	errSpectrum := fft.FFT(errFrame)
+
+	// Оценка мощности входа по бинам
+	for k, v := range newest {
+		mag := real(v)*real(v) + imag(v)*imag(v)
+		f.power[k] = f.beta*f.power[k] + (1-f.beta)*mag
+	}
+	// Коррекция смещения нулевой начальной оценки: без нее на первых блоках
+	// мощность занижена в 1/(1-beta^n) раз, а шаг во столько же раз завышен
+	f.betaPow *= f.beta
+	correction := 1 / (1 - f.betaPow)
+
+	// Обновление весов каждого разбиения
+	grad := make([]complex128, 2*b)
+	for p := 0; p < f.partitions; p++ {
+		x := f.spectra[(f.specPos+p)%f.partitions]
+		for k := range grad {
+			grad[k] = complex(f.mu/(f.power[k]*correction+f.eps), 0) * cmplx.Conj(x[k]) * errSpectrum[k]
+		}
+
+		update := grad
+		if f.constrained {
+			// Ограничение градиента: оставляем только первые B отсчетов линейной корреляции
+			g := fft.IFFT(grad)
+			for i := b; i < 2*b; i++ {
+				g[i] = 0
+			}
+			update = fft.FFT(g)
+		}
+
+		w := f.weights[p]
+		for k := range w {
+			w[k] += update[k]
+		}
+	}
+
+	return output, errSignal, nil
+}
+
+// Process обрабатывает сигналы произвольной длины, кратной blockSize
+func (f *PartitionedFDAF) Process(input, desired []float64) (output, errSignal []float64, err error) {
+	if len(input) != len(desired) || len(input)%f.blockSize != 0 {
+		return nil, nil, &InvalidParameterError{Param: "input", Value: float64(len(input)), Reason: "length must be a multiple of block size and match desired"}
+	}
+
+	output = make([]float64, 0, len(input))
+	errSignal = make([]float64, 0, len(input))
+	for start := 0; start < len(input); start += f.blockSize {
+		y, e, err := f.ProcessBlock(input[start:start+f.blockSize], desired[start:start+f.blockSize])
+		if err != nil {
+			return nil, nil, err
+		}
+		output = append(output, y...)
+		errSignal = append(errSignal, e...)
+	}
+
+	return output, errSignal, nil
+}
+
+// GetWeights возвращает импульсную характеристику фильтра во временной области (длина P*B)
+func (f *PartitionedFDAF) GetWeights() []float64 {
+	b := f.blockSize
+	weights := make([]float64, f.partitions*b)
+	for p := 0; p < f.partitions; p++ {
+		h := fft.IFFT(f.weights[p])
+		for i := 0; i < b; i++ {
+			weights[p*b+i] = real(h[i])
+		}
+	}
+	return weights
+}
+
+// Reset сбрасывает веса и состояние фильтра
+func (f *PartitionedFDAF) Reset() {
+	for p := 0; p < f.partitions; p++ {
+		for k := range f.weights[p] {
+			f.weights[p][k] = 0
+			f.spectra[p][k] = 0
+		}
+	}
+	for k := range f.power {
+		f.power[k] = 0
+	}
+	f.betaPow = 1
+	for i := range f.prevHalf {
+		f.prevHalf[i] = 0
+	}
+	f.specPos = 0
+}
+
+// GetBlockSize возвращает размер блока
+func (f *PartitionedFDAF) GetBlockSize() int {
+	return f.blockSize
+}
+
+// GetLength возвращает длину адаптивного фильтра (P*B)
+func (f *PartitionedFDAF) GetLength() int {
+	return f.partitions * f.blockSize
+}
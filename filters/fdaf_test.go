@@ -0,0 +1,184 @@
+package filters
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// runEchoPath пропускает белый шум через известный эхо-тракт и адаптирует FDAF
+// Возвращает ERLE (дБ) на последних блоках
+func runEchoPath(t *testing.T, f *PartitionedFDAF, echoPath []float64, blocks int) float64 {
+	t.Helper()
+	rng := rand.New(rand.NewSource(3))
+	echo := NewFIRFilter(append([]float64{}, echoPath...))
+	b := f.GetBlockSize()
+
+	var echoPower, residualPower float64
+	for blk := 0; blk < blocks; blk++ {
+		input := make([]float64, b)
+		desired := make([]float64, b)
+		for i := range input {
+			input[i] = rng.NormFloat64()
+			desired[i] = echo.Tick(input[i])
+		}
+
+		_, e, err := f.ProcessBlock(input, desired)
+		if err != nil {
+			t.Fatalf("ProcessBlock failed: %v", err)
+		}
+		if blk >= blocks-20 {
+			for i := range e {
+				echoPower += desired[i] * desired[i]
+				residualPower += e[i] * e[i]
+			}
+		}
+	}
+
+	return 10 * math.Log10(echoPower/residualPower)
+}
+
+// makeEchoPath создает затухающий эхо-тракт заданной длины
+func makeEchoPath(length int) []float64 {
+	rng := rand.New(rand.NewSource(11))
+	path := make([]float64, length)
+	for i := range path {
+		path[i] = rng.NormFloat64() * math.Exp(-float64(i)/float64(length/4))
+	}
+	return path
+}
+
+// TestPartitionedFDAFConvergence проверяет идентификацию длинного эхо-тракта
+func TestPartitionedFDAFConvergence(t *testing.T) {
+	echoPath := makeEchoPath(200)
+
+	f, err := NewPartitionedFDAF(64, 4, 0.5)
+	if err != nil {
+		t.Fatalf("failed to create FDAF: %v", err)
+	}
+	if f.GetLength() != 256 {
+		t.Errorf("expected filter length 256, got %d", f.GetLength())
+	}
+
+	if erle := runEchoPath(t, f, echoPath, 400); erle < 40 {
+		t.Errorf("ERLE %.1f dB, want at least 40 dB", erle)
+	}
+
+	weights := f.GetWeights()
+	for i, w := range weights {
+		want := 0.0
+		if i < len(echoPath) {
+			want = echoPath[i]
+		}
+		if math.Abs(w-want) > 0.01 {
+			t.Fatalf("weight %d = %f, want %f", i, w, want)
+		}
+	}
+
+	f.Reset()
+	for _, w := range f.GetWeights() {
+		if w != 0 {
+			t.Fatal("weights should be zero after reset")
+		}
+	}
+}
+
+// TestFrequencyDomainLMS проверяет вариант без разбиения и без ограничения градиента
+func TestFrequencyDomainLMS(t *testing.T) {
+	echoPath := makeEchoPath(50)
+
+	f, err := NewFrequencyDomainLMS(64, 0.3)
+	if err != nil {
+		t.Fatalf("failed to create FDAF: %v", err)
+	}
+	f.SetConstrained(false)
+
+	if erle := runEchoPath(t, f, echoPath, 600); erle < 25 {
+		t.Errorf("unconstrained ERLE %.1f dB, want at least 25 dB", erle)
+	}
+}
+
+// TestPartitionedFDAFProcess проверяет эквивалентность Process и ProcessBlock
+func TestPartitionedFDAFProcess(t *testing.T) {
+	a, _ := NewPartitionedFDAF(16, 2, 0.5)
+	b, _ := NewPartitionedFDAF(16, 2, 0.5)
+
+	rng := rand.New(rand.NewSource(5))
+	input := make([]float64, 160)
+	desired := make([]float64, 160)
+	for i := range input {
+		input[i] = rng.NormFloat64()
+		desired[i] = 0.5 * input[i]
+	}
+
+	outA, _, err := a.Process(input, desired)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	for start := 0; start < len(input); start += 16 {
+		outB, _, _ := b.ProcessBlock(input[start:start+16], desired[start:start+16])
+		for i := range outB {
+			if outA[start+i] != outB[i] {
+				t.Fatalf("output mismatch at %d", start+i)
+			}
+		}
+	}
+
+	if _, _, err := a.Process(input[:10], desired[:10]); err == nil {
+		t.Error("expected error for length not multiple of block size")
+	}
+	if _, err := NewPartitionedFDAF(48, 2, 0.5); err == nil {
+		t.Error("expected error for non power-of-two block size")
+	}
+	if err := a.SetPowerSmoothing(1); err == nil {
+		t.Error("expected error for smoothing factor 1")
+	}
+}
+
+// TestPartitionedFDAFStartup проверяет, что на первых блоках фильтр не расходится:
+// нулевая начальная оценка мощности не должна завышать шаг адаптации
+func TestPartitionedFDAFStartup(t *testing.T) {
+	echoPath := makeEchoPath(60)
+	var pathEnergy float64
+	for _, v := range echoPath {
+		pathEnergy += v * v
+	}
+
+	f, err := NewPartitionedFDAF(64, 1, 1.0)
+	if err != nil {
+		t.Fatalf("failed to create FDAF: %v", err)
+	}
+	for run := 0; run < 2; run++ {
+		rng := rand.New(rand.NewSource(5))
+		echo := NewFIRFilter(append([]float64{}, echoPath...))
+		for blk := 0; blk < 10; blk++ {
+			input := make([]float64, 64)
+			desired := make([]float64, 64)
+			for i := range input {
+				input[i] = rng.NormFloat64()
+				desired[i] = echo.Tick(input[i])
+			}
+			_, e, err := f.ProcessBlock(input, desired)
+			if err != nil {
+				t.Fatalf("ProcessBlock failed: %v", err)
+			}
+
+			var errPower, desiredPower, weightEnergy float64
+			for i := range e {
+				errPower += e[i] * e[i]
+				desiredPower += desired[i] * desired[i]
+			}
+			for _, w := range f.GetWeights() {
+				weightEnergy += w * w
+			}
+			if errPower > desiredPower*1.01 {
+				t.Errorf("run %d block %d: error power %.3g exceeds echo power %.3g", run, blk, errPower, desiredPower)
+			}
+			if ratio := math.Sqrt(weightEnergy / pathEnergy); ratio > 1.5 {
+				t.Errorf("run %d block %d: weight norm is %.2f times the echo path norm", run, blk, ratio)
+			}
+		}
+		// После Reset начальный участок должен повторяться
+		f.Reset()
+	}
+}
@@ -65,3 +65,14 @@ func (f *FIRFilter) GetCoefficients() []float64 {
 func (f *FIRFilter) GetBufferSize() int {
 	return len(f.buffer)
 }
+
+// SetCoefficients заменяет коэффициенты фильтра без сброса буфера задержки
+// Используется для фильтров с изменяющимися во времени параметрами.
+// Длина нового набора должна совпадать с текущей. Коэффициенты копируются в новый срез,
+// поэтому срез, переданный в NewFIRFilter, не изменяется.
+func (f *FIRFilter) SetCoefficients(coeffs []float64) {
+	if len(coeffs) != len(f.coeffs) {
+		panic("FIRFilter: coefficient count cannot change")
+	}
+	f.coeffs = append([]float64(nil), coeffs...)
+}
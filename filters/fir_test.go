@@ -264,6 +264,22 @@ func TestConsecutiveProcessing(t *testing.T) {
 	}
 }
 
+// TestSetCoefficientsDoesNotAlias проверяет, что SetCoefficients не меняет срез,
+// переданный в конструктор, и сохраняет состояние буфера
+func TestSetCoefficientsDoesNotAlias(t *testing.T) {
+	coeffs := []float64{1, 0, 0}
+	filter := NewFIRFilter(coeffs)
+	filter.Tick(5)
+
+	filter.SetCoefficients([]float64{0, 1, 0})
+	if coeffs[0] != 1 || coeffs[1] != 0 {
+		t.Errorf("caller's coefficients changed to %v", coeffs)
+	}
+	if y := filter.Tick(0); y != 5 {
+		t.Errorf("expected delayed sample 5 after SetCoefficients, got %f", y)
+	}
+}
+
 // BenchmarkFIRFilterTick тестирует производительность
 func BenchmarkFIRFilterTick(b *testing.B) {
 	// Фильтр с 64 коэффициентами
@@ -0,0 +1,363 @@
+package filters
+
+import (
+	"math"
+
+	"github.com/Alexxtn105/dsp/windows"
+)
+
+// FractionalDelayMethod определяет метод расчета фильтра дробной задержки
+type FractionalDelayMethod int
+
+const (
+	LagrangeDelay FractionalDelayMethod = iota // КИХ-фильтр на основе интерполяции Лагранжа
+	SincDelay                                  // КИХ-фильтр на основе оконного sinc (окно Кайзера)
+	ThiranDelay                                // Всепропускающий БИХ-фильтр Тирана
+)
+
+// sincDelayKaiserBeta - параметр окна Кайзера для фильтра оконного sinc
+const sincDelayKaiserBeta = 6.0
+
+// String возвращает строковое представление метода
+func (m FractionalDelayMethod) String() string {
+	switch m {
+	case LagrangeDelay:
+		return "Лагранж"
+	case SincDelay:
+		return "Оконный sinc"
+	case ThiranDelay:
+		return "Тиран"
+	default:
+		return "Неизвестный"
+	}
+}
+
+// DesignLagrangeDelay рассчитывает коэффициенты КИХ-фильтра дробной задержки методом Лагранжа
+// h[k] = prod_{i != k} (delay - i) / (k - i), k = 0..order
+// Наилучшая точность достигается при delay, близкой к order/2.
+func DesignLagrangeDelay(delay float64, order int) ([]float64, error) {
+	if order < 1 {
+		return nil, &InvalidParameterError{Param: "order", Value: float64(order), Reason: "order must be at least 1"}
+	}
+	if delay < 0 || delay > float64(order) {
+		return nil, &InvalidParameterError{Param: "delay", Value: delay, Reason: "delay must be between 0 and order"}
+	}
+
+	coeffs := make([]float64, order+1)
+	for k := 0; k <= order; k++ {
+		h := 1.0
+		for i := 0; i <= order; i++ {
+			if i != k {
+				h *= (delay - float64(i)) / float64(k-i)
+			}
+		}
+		coeffs[k] = h
+	}
+
+	return coeffs, nil
+}
+
+// DesignSincDelay рассчитывает коэффициенты КИХ-фильтра дробной задержки методом оконного sinc
+// h[n] = sinc(n - delay) * w[n], где w - окно Кайзера; коэффициенты нормируются на единичное усиление.
+// Наилучшая точность достигается при delay, близкой к (numTaps-1)/2.
+func DesignSincDelay(delay float64, numTaps int, beta float64) ([]float64, error) {
+	if numTaps < 2 {
+		return nil, &InvalidParameterError{Param: "numTaps", Value: float64(numTaps), Reason: "at least 2 taps are required"}
+	}
+	if delay < 0 || delay > float64(numTaps-1) {
+		return nil, &InvalidParameterError{Param: "delay", Value: delay, Reason: "delay must be between 0 and numTaps-1"}
+	}
+
+	ideal := make([]float64, numTaps)
+	for n := range ideal {
+		x := float64(n) - delay
+		if math.Abs(x) < 1e-12 {
+			ideal[n] = 1
+		} else {
+			ideal[n] = math.Sin(math.Pi*x) / (math.Pi * x)
+		}
+	}
+
+	coeffs := windows.ApplyKaiserWindow(ideal, beta)
+
+	var sum float64
+	for _, h := range coeffs {
+		sum += h
+	}
+	for n := range coeffs {
+		coeffs[n] /= sum
+	}
+
+	return coeffs, nil
+}
+
+// DesignThiranDelay рассчитывает коэффициенты всепропускающего фильтра Тирана
+// a[k] = (-1)^k * C(N,k) * prod_{i=0}^{N} (delay-N+i) / (delay-N+k+i), b[k] = a[N-k]
+// Фильтр имеет максимально плоскую групповую задержку, равную delay, на нулевой частоте
+// и устойчив при delay > order-1.
+func DesignThiranDelay(delay float64, order int) (bCoeffs, aCoeffs []float64, err error) {
+	if order < 1 {
+		return nil, nil, &InvalidParameterError{Param: "order", Value: float64(order), Reason: "order must be at least 1"}
+	}
+	if delay <= float64(order-1) {
+		return nil, nil, &InvalidParameterError{Param: "delay", Value: delay, Reason: "delay must be greater than order-1 for stability"}
+	}
+
+	n := float64(order)
+	aCoeffs = make([]float64, order+1)
+	binom := 1.0
+	for k := 0; k <= order; k++ {
+		if k > 0 {
+			binom *= float64(order-k+1) / float64(k)
+		}
+		a := binom
+		if k%2 == 1 {
+			a = -a
+		}
+		for i := 0; i <= order; i++ {
+			a *= (delay - n + float64(i)) / (delay - n + float64(k+i))
+		}
+		aCoeffs[k] = a
+	}
+
+	bCoeffs = make([]float64, order+1)
+	for k := range bCoeffs {
+		bCoeffs[k] = aCoeffs[order-k]
+	}
+
+	return bCoeffs, aCoeffs, nil
+}
+
+// FractionalDelay реализует фильтр дробной задержки с возможностью изменения задержки во времени
+// Коэффициенты пересчитываются при вызове SetDelay без сброса состояния фильтра,
+// поэтому задержку можно плавно изменять на каждом отсчете.
+type FractionalDelay struct {
+	method FractionalDelayMethod // Метод расчета
+	order  int                   // Порядок фильтра (для SincDelay - число коэффициентов минус 1)
+	delay  float64               // Текущая задержка в отсчетах
+
+	fir *FIRFilter // КИХ-реализация (Лагранж, sinc)
+	iir *IIRFilter // БИХ-реализация (Тиран)
+}
+
+// NewFractionalDelay создает фильтр дробной задержки
+// method: метод расчета
+// order: порядок фильтра
+// delay: задержка в отсчетах (допустимый диапазон зависит от метода)
+func NewFractionalDelay(method FractionalDelayMethod, order int, delay float64) (*FractionalDelay, error) {
+	fd := &FractionalDelay{method: method, order: order}
+
+	switch method {
+	case LagrangeDelay, SincDelay:
+		coeffs, err := fd.designFIR(delay)
+		if err != nil {
+			return nil, err
+		}
+		fd.fir = NewFIRFilter(coeffs)
+	case ThiranDelay:
+		b, a, err := DesignThiranDelay(delay, order)
+		if err != nil {
+			return nil, err
+		}
+		fd.iir = NewIIRFilter(b, a)
+	default:
+		return nil, &InvalidParameterError{Param: "method", Value: float64(method), Reason: "unknown fractional delay method"}
+	}
+
+	fd.delay = delay
+	return fd, nil
+}
+
+// designFIR рассчитывает КИХ-коэффициенты для текущего метода
+func (fd *FractionalDelay) designFIR(delay float64) ([]float64, error) {
+	if fd.method == SincDelay {
+		return DesignSincDelay(delay, fd.order+1, sincDelayKaiserBeta)
+	}
+	return DesignLagrangeDelay(delay, fd.order)
+}
+
+// SetDelay изменяет задержку без сброса состояния фильтра
+func (fd *FractionalDelay) SetDelay(delay float64) error {
+	if fd.iir != nil {
+		b, a, err := DesignThiranDelay(delay, fd.order)
+		if err != nil {
+			return err
+		}
+		fd.iir.SetCoefficients(b, a)
+	} else {
+		coeffs, err := fd.designFIR(delay)
+		if err != nil {
+			return err
+		}
+		fd.fir.SetCoefficients(coeffs)
+	}
+
+	fd.delay = delay
+	return nil
+}
+
+// Tick обрабатывает один входной отсчет
+func (fd *FractionalDelay) Tick(input float64) float64 {
+	if fd.iir != nil {
+		return fd.iir.Tick(input)
+	}
+	return fd.fir.Tick(input)
+}
+
+// Process обрабатывает срез входных отсчетов
+func (fd *FractionalDelay) Process(input []float64) []float64 {
+	output := make([]float64, len(input))
+	for i, x := range input {
+		output[i] = fd.Tick(x)
+	}
+	return output
+}
+
+// Reset сбрасывает состояние фильтра
+func (fd *FractionalDelay) Reset() {
+	if fd.iir != nil {
+		fd.iir.Reset()
+	} else {
+		fd.fir.Reset()
+	}
+}
+
+// GetDelay возвращает текущую задержку в отсчетах
+func (fd *FractionalDelay) GetDelay() float64 {
+	return fd.delay
+}
+
+// GetMethod возвращает метод расчета фильтра
+func (fd *FractionalDelay) GetMethod() FractionalDelayMethod {
+	return fd.method
+}
+
+// DelayAndSumBeamformer реализует формирователь луча "задержка-и-сумма"
+// Каждый канал задерживается на целое число отсчетов (линия задержки) и дробную часть
+// (фильтр Лагранжа), после чего каналы взвешенно суммируются.
+// Все каналы дополнительно задерживаются на общую задержку GetLatency(),
+// необходимую для работы дробного фильтра в области наилучшей точности.
+type DelayAndSumBeamformer struct {
+	order    int       // Порядок фильтров Лагранжа
+	maxDelay float64   // Максимальная задержка канала в отсчетах
+	lineSize int       // Длина буферов целых задержек: floor(maxDelay)+1
+	weights  []float64 // Весовые коэффициенты каналов
+
+	lines    [][]float64        // Кольцевые буферы целых задержек
+	linePos  int                // Позиция записи в буферах
+	intDelay []int              // Целые части задержек каналов
+	frac     []*FractionalDelay // Дробные части задержек каналов
+	delays   []float64          // Полные задержки каналов
+}
+
+// NewDelayAndSumBeamformer создает формирователь луча
+// numChannels: количество каналов
+// maxDelay: максимальная задержка канала в отсчетах
+// order: порядок фильтров Лагранжа (рекомендуется нечетный, например 3)
+func NewDelayAndSumBeamformer(numChannels int, maxDelay float64, order int) (*DelayAndSumBeamformer, error) {
+	if numChannels < 1 {
+		return nil, &InvalidParameterError{Param: "numChannels", Value: float64(numChannels), Reason: "at least one channel is required"}
+	}
+	if maxDelay < 0 {
+		return nil, &InvalidParameterError{Param: "maxDelay", Value: maxDelay, Reason: "maximum delay must be non-negative"}
+	}
+	if order < 1 {
+		return nil, &InvalidParameterError{Param: "order", Value: float64(order), Reason: "order must be at least 1"}
+	}
+
+	bf := &DelayAndSumBeamformer{
+		order:    order,
+		maxDelay: maxDelay,
+		lineSize: int(math.Floor(maxDelay)) + 1,
+		weights:  make([]float64, numChannels),
+		lines:    make([][]float64, numChannels),
+		intDelay: make([]int, numChannels),
+		frac:     make([]*FractionalDelay, numChannels),
+		delays:   make([]float64, numChannels),
+	}
+
+	for ch := 0; ch < numChannels; ch++ {
+		bf.weights[ch] = 1.0 / float64(numChannels)
+		bf.lines[ch] = make([]float64, bf.lineSize)
+		fd, err := NewFractionalDelay(LagrangeDelay, order, float64(bf.GetLatency()))
+		if err != nil {
+			return nil, err
+		}
+		bf.frac[ch] = fd
+	}
+
+	return bf, nil
+}
+
+// SetDelays устанавливает задержки каналов в отсчетах
+// Может вызываться во время работы для перенацеливания луча.
+func (bf *DelayAndSumBeamformer) SetDelays(delays []float64) error {
+	if len(delays) != len(bf.frac) {
+		return &InvalidParameterError{Param: "delays", Value: float64(len(delays)), Reason: "delay count must match channel count"}
+	}
+	for _, d := range delays {
+		if d < 0 || d > bf.maxDelay {
+			return &InvalidParameterError{Param: "delays", Value: d, Reason: "delay must be between 0 and maxDelay"}
+		}
+	}
+
+	latency := float64(bf.GetLatency())
+	for ch, d := range delays {
+		whole := math.Floor(d)
+		if err := bf.frac[ch].SetDelay(latency + d - whole); err != nil {
+			return err
+		}
+		bf.intDelay[ch] = int(whole)
+		bf.delays[ch] = d
+	}
+
+	return nil
+}
+
+// SetWeights устанавливает весовые коэффициенты каналов
+func (bf *DelayAndSumBeamformer) SetWeights(weights []float64) error {
+	if len(weights) != len(bf.weights) {
+		return &InvalidParameterError{Param: "weights", Value: float64(len(weights)), Reason: "weight count must match channel count"}
+	}
+	copy(bf.weights, weights)
+	return nil
+}
+
+// Tick обрабатывает по одному отсчету каждого канала и возвращает сумму
+func (bf *DelayAndSumBeamformer) Tick(inputs []float64) float64 {
+	if len(inputs) != len(bf.frac) {
+		panic("DelayAndSumBeamformer: input count must match channel count")
+	}
+
+	size := bf.lineSize
+	var output float64
+	for ch, x := range inputs {
+		bf.lines[ch][bf.linePos] = x
+		idx := (bf.linePos - bf.intDelay[ch] + size) % size
+		output += bf.weights[ch] * bf.frac[ch].Tick(bf.lines[ch][idx])
+	}
+	bf.linePos = (bf.linePos + 1) % size
+
+	return output
+}
+
+// Reset сбрасывает состояние всех каналов
+func (bf *DelayAndSumBeamformer) Reset() {
+	for ch := range bf.lines {
+		for i := range bf.lines[ch] {
+			bf.lines[ch][i] = 0
+		}
+		bf.frac[ch].Reset()
+	}
+	bf.linePos = 0
+}
+
+// GetLatency возвращает общую задержку, добавляемую ко всем каналам (в отсчетах)
+func (bf *DelayAndSumBeamformer) GetLatency() int {
+	return (bf.order - 1) / 2
+}
+
+// GetDelays возвращает копию текущих задержек каналов
+func (bf *DelayAndSumBeamformer) GetDelays() []float64 {
+	return append([]float64{}, bf.delays...)
+}
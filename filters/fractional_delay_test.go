@@ -0,0 +1,185 @@
+package filters
+
+import (
+	"math"
+	"testing"
+)
+
+// delayError возвращает максимальную ошибку задержки синусоиды в установившемся режиме
+func delayError(fd *FractionalDelay, freq, delay float64) float64 {
+	var maxErr float64
+	for n := 0; n < 2000; n++ {
+		y := fd.Tick(math.Sin(2 * math.Pi * freq * float64(n)))
+		if n < 500 {
+			continue
+		}
+		want := math.Sin(2 * math.Pi * freq * (float64(n) - delay))
+		maxErr = math.Max(maxErr, math.Abs(y-want))
+	}
+	return maxErr
+}
+
+// TestDesignLagrangeDelay проверяет свойства коэффициентов Лагранжа
+func TestDesignLagrangeDelay(t *testing.T) {
+	// Целая задержка дает единичный импульс
+	coeffs, err := DesignLagrangeDelay(2, 3)
+	if err != nil {
+		t.Fatalf("failed to design Lagrange filter: %v", err)
+	}
+	for k, h := range coeffs {
+		want := 0.0
+		if k == 2 {
+			want = 1
+		}
+		if math.Abs(h-want) > 1e-12 {
+			t.Errorf("coeffs[%d] = %f, want %f", k, h, want)
+		}
+	}
+
+	// Полупериодная задержка первого порядка - линейная интерполяция
+	coeffs, _ = DesignLagrangeDelay(0.5, 1)
+	if math.Abs(coeffs[0]-0.5) > 1e-12 || math.Abs(coeffs[1]-0.5) > 1e-12 {
+		t.Errorf("first-order half-sample delay should be [0.5 0.5], got %v", coeffs)
+	}
+
+	if _, err := DesignLagrangeDelay(4, 3); err == nil {
+		t.Error("expected error for delay beyond order")
+	}
+}
+
+// TestFractionalDelayMethods проверяет точность задержки всех методов
+func TestFractionalDelayMethods(t *testing.T) {
+	tests := []struct {
+		method FractionalDelayMethod
+		order  int
+		delay  float64
+		tol    float64
+	}{
+		{LagrangeDelay, 3, 1.3, 1e-3},
+		{SincDelay, 31, 15.4, 1e-3},
+		{ThiranDelay, 3, 3.3, 1e-3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method.String(), func(t *testing.T) {
+			fd, err := NewFractionalDelay(tt.method, tt.order, tt.delay)
+			if err != nil {
+				t.Fatalf("failed to create filter: %v", err)
+			}
+			if e := delayError(fd, 0.01, tt.delay); e > tt.tol {
+				t.Errorf("max delay error %g exceeds %g", e, tt.tol)
+			}
+		})
+	}
+
+	if _, err := NewFractionalDelay(ThiranDelay, 3, 1.5); err == nil {
+		t.Error("expected error for unstable Thiran delay")
+	}
+}
+
+// TestThiranIsAllPass проверяет единичную АЧХ фильтра Тирана
+func TestThiranIsAllPass(t *testing.T) {
+	b, a, err := DesignThiranDelay(4.7, 4)
+	if err != nil {
+		t.Fatalf("failed to design Thiran filter: %v", err)
+	}
+	iir := NewIIRFilter(b, a)
+	for _, f := range []float64{0.01, 0.1, 0.25, 0.4, 0.49} {
+		if mag := math.Hypot(real(iir.GetFrequencyResponse(f)), imag(iir.GetFrequencyResponse(f))); math.Abs(mag-1) > 1e-9 {
+			t.Errorf("magnitude at f=%.2f is %f, want 1", f, mag)
+		}
+	}
+	if gd := iir.GetGroupDelay(0.001); math.Abs(gd-4.7) > 1e-3 {
+		t.Errorf("group delay at DC is %f, want 4.7", gd)
+	}
+}
+
+// TestFractionalDelayTimeVarying проверяет изменение задержки без сброса состояния
+func TestFractionalDelayTimeVarying(t *testing.T) {
+	fd, err := NewFractionalDelay(LagrangeDelay, 3, 1.0)
+	if err != nil {
+		t.Fatalf("failed to create filter: %v", err)
+	}
+
+	// Медленно меняющаяся задержка: выход следует за входом без разрывов
+	freq := 0.005
+	for n := 0; n < 3000; n++ {
+		delay := 1.5 + 0.4*math.Sin(2*math.Pi*float64(n)/1000)
+		if err := fd.SetDelay(delay); err != nil {
+			t.Fatalf("SetDelay failed: %v", err)
+		}
+		y := fd.Tick(math.Sin(2 * math.Pi * freq * float64(n)))
+		if n < 10 {
+			continue
+		}
+		want := math.Sin(2 * math.Pi * freq * (float64(n) - delay))
+		if math.Abs(y-want) > 1e-3 {
+			t.Fatalf("sample %d: got %f, want %f", n, y, want)
+		}
+	}
+
+	if fd.GetDelay() == 1.0 {
+		t.Error("delay should have been updated")
+	}
+	if err := fd.SetDelay(5); err == nil {
+		t.Error("expected error for delay beyond filter order")
+	}
+}
+
+// TestDelayAndSumBeamformer проверяет выравнивание каналов с дробной задержкой
+func TestDelayAndSumBeamformer(t *testing.T) {
+	bf, err := NewDelayAndSumBeamformer(3, 8, 3)
+	if err != nil {
+		t.Fatalf("failed to create beamformer: %v", err)
+	}
+
+	// Волна приходит на каналы с задержками 0, 2.4 и 5.7 отсчета
+	arrivals := []float64{0, 2.4, 5.7}
+	// Для выравнивания задерживаем ранние каналы сильнее
+	if err := bf.SetDelays([]float64{5.7, 3.3, 0}); err != nil {
+		t.Fatalf("SetDelays failed: %v", err)
+	}
+
+	freq := 0.02
+	totalDelay := 5.7 + float64(bf.GetLatency())
+	for n := 0; n < 1000; n++ {
+		inputs := make([]float64, len(arrivals))
+		for ch, d := range arrivals {
+			inputs[ch] = math.Sin(2 * math.Pi * freq * (float64(n) - d))
+		}
+		y := bf.Tick(inputs)
+		if n < 50 {
+			continue
+		}
+		want := math.Sin(2 * math.Pi * freq * (float64(n) - totalDelay))
+		if math.Abs(y-want) > 1e-3 {
+			t.Fatalf("sample %d: coherent sum %f, want %f", n, y, want)
+		}
+	}
+
+	if err := bf.SetDelays([]float64{1, 2}); err == nil {
+		t.Error("expected error for wrong delay count")
+	}
+	if err := bf.SetDelays([]float64{1, 2, 10}); err == nil {
+		t.Error("expected error for delay beyond maximum")
+	}
+
+	// Граница задается дробным maxDelay, а не длиной буфера
+	for _, c := range []struct {
+		maxDelay, delay float64
+		ok              bool
+	}{
+		{2.0, 2.0, true},
+		{2.0, 2.9, false},
+		{2.5, 2.5, true},
+		{2.5, 2.6, false},
+	} {
+		bf, err := NewDelayAndSumBeamformer(1, c.maxDelay, 3)
+		if err != nil {
+			t.Fatalf("failed to create beamformer: %v", err)
+		}
+		if err := bf.SetDelays([]float64{c.delay}); (err == nil) != c.ok {
+			t.Errorf("maxDelay %.1f, delay %.1f: error %v", c.maxDelay, c.delay, err)
+		}
+	}
+}
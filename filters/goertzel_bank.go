@@ -0,0 +1,218 @@
+package filters
+
+import (
+	"math"
+	"math/cmplx"
+	"sync"
+)
+
+// GoertzelBankResult содержит результаты анализа блока банком фильтров Герцеля
+// Все срезы упорядочены так же, как частоты, переданные в NewGoertzelBank
+type GoertzelBankResult struct {
+	Frequencies []float64    // Анализируемые частоты (Гц)
+	DFT         []complex128 // Комплексные значения спектра
+	Magnitudes  []float64    // Амплитуды (2|X|/N)
+	Powers      []float64    // Мощности (magnitude^2/2)
+	Phases      []float64    // Фазы относительно начала блока [-π, π]
+}
+
+// MagnitudeOf возвращает амплитуду для заданной частоты банка
+func (r *GoertzelBankResult) MagnitudeOf(freq float64) (float64, bool) {
+	for i, f := range r.Frequencies {
+		if f == freq {
+			return r.Magnitudes[i], true
+		}
+	}
+	return 0, false
+}
+
+// MagnitudeMap возвращает амплитуды в виде отображения частота -> амплитуда
+func (r *GoertzelBankResult) MagnitudeMap() map[float64]float64 {
+	m := make(map[float64]float64, len(r.Frequencies))
+	for i, f := range r.Frequencies {
+		m[f] = r.Magnitudes[i]
+	}
+	return m
+}
+
+// GoertzelBank реализует банк обобщенных фильтров Герцеля
+// Все бины обновляются в одном общем цикле по отсчетам, что дешевле, чем
+// подавать каждый отсчет в отдельные экземпляры GoertzelFilter.
+// Большие банки при блочной обработке можно распределить по нескольким горутинам.
+type GoertzelBank struct {
+	freqs        []float64    // Анализируемые частоты (Гц)
+	samplingRate float64      // Частота дискретизации (Гц)
+	blockSize    int          // Длина блока анализа N
+	coeffs       []float64    // 2*cos(w) для каждого бина
+	expW         []complex128 // e^(-jw) для каждого бина
+	phaseCorr    []complex128 // e^(-jw(N-1)) для каждого бина
+	q1           []float64    // Состояния q[n-1]
+	q2           []float64    // Состояния q[n-2]
+	n            int          // Количество обработанных отсчетов текущего блока
+	workers      int          // Количество горутин для ProcessBlock
+}
+
+// minBinsPerWorker - минимальное число бинов на горутину, при котором имеет смысл распараллеливание
+const minBinsPerWorker = 16
+
+// NewGoertzelBank создает банк фильтров Герцеля
+// freqs: список частот в Гц (0 < f < samplingRate/2), частоты не округляются до бинов ДПФ
+// blockSize: длина блока анализа N
+func NewGoertzelBank(freqs []float64, samplingRate float64, blockSize int) (*GoertzelBank, error) {
+	if len(freqs) == 0 {
+		return nil, &InvalidParameterError{Param: "freqs", Value: 0, Reason: "at least one frequency is required"}
+	}
+	if samplingRate <= 0 {
+		return nil, &InvalidParameterError{Param: "samplingRate", Value: samplingRate, Reason: "sampling rate must be positive"}
+	}
+	if blockSize <= 0 {
+		return nil, &InvalidParameterError{Param: "blockSize", Value: float64(blockSize), Reason: "block size must be positive"}
+	}
+
+	bank := &GoertzelBank{
+		freqs:        append([]float64{}, freqs...),
+		samplingRate: samplingRate,
+		blockSize:    blockSize,
+		coeffs:       make([]float64, len(freqs)),
+		expW:         make([]complex128, len(freqs)),
+		phaseCorr:    make([]complex128, len(freqs)),
+		q1:           make([]float64, len(freqs)),
+		q2:           make([]float64, len(freqs)),
+		workers:      1,
+	}
+
+	for i, f := range freqs {
+		if f <= 0 || f >= samplingRate/2 {
+			return nil, &InvalidParameterError{Param: "freqs", Value: f, Reason: "frequency must be between 0 and Nyquist frequency"}
+		}
+		w := 2 * math.Pi * f / samplingRate
+		bank.coeffs[i] = 2 * math.Cos(w)
+		bank.expW[i] = cmplx.Exp(complex(0, -w))
+		bank.phaseCorr[i] = cmplx.Exp(complex(0, -w*float64(blockSize-1)))
+	}
+
+	return bank, nil
+}
+
+// SetWorkers устанавливает количество горутин для ProcessBlock
+// Банк делится на части не менее чем по minBinsPerWorker бинов.
+func (b *GoertzelBank) SetWorkers(workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+	b.workers = workers
+}
+
+// Process обрабатывает один отсчет во всех бинах
+func (b *GoertzelBank) Process(input float64) error {
+	if b.n >= b.blockSize {
+		return &InvalidStateError{Reason: "all samples have already been processed"}
+	}
+	b.update(input, 0, len(b.freqs))
+	b.n++
+	return nil
+}
+
+// update выполняет шаг рекурсии Герцеля для бинов [from, to)
+func (b *GoertzelBank) update(input float64, from, to int) {
+	q1 := b.q1[from:to]
+	q2 := b.q2[from:to]
+	coeffs := b.coeffs[from:to]
+	for i := range q1 {
+		q0 := input + coeffs[i]*q1[i] - q2[i]
+		q2[i] = q1[i]
+		q1[i] = q0
+	}
+}
+
+// ProcessBlock анализирует блок длины blockSize и возвращает результаты для всех частот
+// Состояние банка сбрасывается перед обработкой.
+func (b *GoertzelBank) ProcessBlock(samples []float64) (*GoertzelBankResult, error) {
+	if len(samples) != b.blockSize {
+		return nil, &InvalidParameterError{Param: "samples", Value: float64(len(samples)), Reason: "block length must equal block size"}
+	}
+	b.Reset()
+
+	workers := b.workers
+	if maxWorkers := len(b.freqs) / minBinsPerWorker; workers > maxWorkers {
+		workers = maxWorkers
+	}
+
+	if workers <= 1 {
+		for _, x := range samples {
+			b.update(x, 0, len(b.freqs))
+		}
+	} else {
+		// Каждая горутина проходит по отсчетам для своего непересекающегося диапазона бинов
+		var wg sync.WaitGroup
+		chunk := (len(b.freqs) + workers - 1) / workers
+		for from := 0; from < len(b.freqs); from += chunk {
+			to := min(from+chunk, len(b.freqs))
+			wg.Add(1)
+			go func(from, to int) {
+				defer wg.Done()
+				for _, x := range samples {
+					b.update(x, from, to)
+				}
+			}(from, to)
+		}
+		wg.Wait()
+	}
+	b.n = b.blockSize
+
+	return b.GetResult()
+}
+
+// GetResult возвращает результаты анализа после обработки полного блока
+func (b *GoertzelBank) GetResult() (*GoertzelBankResult, error) {
+	if b.n < b.blockSize {
+		return nil, &InvalidStateError{Reason: "not all samples have been processed yet"}
+	}
+
+	count := len(b.freqs)
+	result := &GoertzelBankResult{
+		Frequencies: append([]float64{}, b.freqs...),
+		DFT:         make([]complex128, count),
+		Magnitudes:  make([]float64, count),
+		Powers:      make([]float64, count),
+		Phases:      make([]float64, count),
+	}
+
+	scale := 2 / float64(b.blockSize)
+	for i := 0; i < count; i++ {
+		y := complex(b.q1[i], 0) - b.expW[i]*complex(b.q2[i], 0)
+		x := y * b.phaseCorr[i]
+		magnitude := cmplx.Abs(x) * scale
+
+		result.DFT[i] = x
+		result.Magnitudes[i] = magnitude
+		result.Powers[i] = magnitude * magnitude / 2
+		result.Phases[i] = cmplx.Phase(x)
+	}
+
+	return result, nil
+}
+
+// Reset сбрасывает состояние всех бинов
+func (b *GoertzelBank) Reset() {
+	for i := range b.q1 {
+		b.q1[i] = 0
+		b.q2[i] = 0
+	}
+	b.n = 0
+}
+
+// IsComplete возвращает true, если обработан полный блок
+func (b *GoertzelBank) IsComplete() bool {
+	return b.n >= b.blockSize
+}
+
+// GetFrequencies возвращает копию списка частот банка
+func (b *GoertzelBank) GetFrequencies() []float64 {
+	return append([]float64{}, b.freqs...)
+}
+
+// GetBlockSize возвращает длину блока анализа
+func (b *GoertzelBank) GetBlockSize() int {
+	return b.blockSize
+}
@@ -0,0 +1,132 @@
+package filters
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// TestGoertzelBankMatchesSingleFilters сравнивает банк с отдельными обобщенными фильтрами
+func TestGoertzelBankMatchesSingleFilters(t *testing.T) {
+	samplingRate := 8000.0
+	blockSize := 205
+	freqs := []float64{697, 770, 852, 941, 1209, 1336, 1477, 1633}
+
+	signal := make([]float64, blockSize)
+	for i := range signal {
+		ti := float64(i) / samplingRate
+		signal[i] = math.Sin(2*math.Pi*770*ti) + math.Sin(2*math.Pi*1336*ti+1)
+	}
+
+	bank, err := NewGoertzelBank(freqs, samplingRate, blockSize)
+	if err != nil {
+		t.Fatalf("failed to create bank: %v", err)
+	}
+	result, err := bank.ProcessBlock(signal)
+	if err != nil {
+		t.Fatalf("ProcessBlock failed: %v", err)
+	}
+
+	for i, f := range freqs {
+		single, _ := NewGeneralizedGoertzelFilter(f, samplingRate, blockSize)
+		_ = single.ProcessBlock(signal)
+		want, _ := single.GetDFT()
+		if cmplx.Abs(result.DFT[i]-want) > 1e-9 {
+			t.Errorf("freq %.0f: bank DFT %v, single filter %v", f, result.DFT[i], want)
+		}
+	}
+
+	// Два присутствующих тона имеют амплитуду около 1, остальные малы
+	magnitudes := result.MagnitudeMap()
+	for _, f := range freqs {
+		present := f == 770 || f == 1336
+		if present && math.Abs(magnitudes[f]-1) > 0.1 {
+			t.Errorf("tone %.0f Hz magnitude %f, want ~1", f, magnitudes[f])
+		}
+		if !present && magnitudes[f] > 0.2 {
+			t.Errorf("absent tone %.0f Hz magnitude %f, want small", f, magnitudes[f])
+		}
+	}
+	if m, ok := result.MagnitudeOf(770); !ok || m != magnitudes[770] {
+		t.Error("MagnitudeOf should match MagnitudeMap")
+	}
+}
+
+// TestGoertzelBankParallel проверяет совпадение параллельной и последовательной обработки
+func TestGoertzelBankParallel(t *testing.T) {
+	samplingRate := 48000.0
+	blockSize := 1024
+
+	freqs := make([]float64, 200)
+	for i := range freqs {
+		freqs[i] = 100 + float64(i)*113.7
+	}
+
+	signal := make([]float64, blockSize)
+	for i := range signal {
+		signal[i] = math.Sin(2*math.Pi*5000*float64(i)/samplingRate) + 0.3*math.Cos(0.37*float64(i))
+	}
+
+	serial, _ := NewGoertzelBank(freqs, samplingRate, blockSize)
+	parallel, _ := NewGoertzelBank(freqs, samplingRate, blockSize)
+	parallel.SetWorkers(4)
+
+	a, err := serial.ProcessBlock(signal)
+	if err != nil {
+		t.Fatalf("serial ProcessBlock failed: %v", err)
+	}
+	b, err := parallel.ProcessBlock(signal)
+	if err != nil {
+		t.Fatalf("parallel ProcessBlock failed: %v", err)
+	}
+
+	for i := range freqs {
+		if a.DFT[i] != b.DFT[i] || a.Phases[i] != b.Phases[i] || a.Powers[i] != b.Powers[i] {
+			t.Fatalf("bin %d differs between serial and parallel processing", i)
+		}
+	}
+}
+
+// TestGoertzelBankStreaming проверяет посэмпловую обработку и ошибки состояния
+func TestGoertzelBankStreaming(t *testing.T) {
+	freqs := []float64{1000, 2000}
+	bank, _ := NewGoertzelBank(freqs, 8000, 64)
+
+	signal := make([]float64, 64)
+	for i := range signal {
+		signal[i] = math.Cos(2 * math.Pi * 1000 * float64(i) / 8000)
+	}
+
+	if _, err := bank.GetResult(); err == nil {
+		t.Error("expected error before block is complete")
+	}
+	for _, x := range signal {
+		if err := bank.Process(x); err != nil {
+			t.Fatalf("Process failed: %v", err)
+		}
+	}
+	if err := bank.Process(0); err == nil {
+		t.Error("expected error when processing beyond block size")
+	}
+
+	streamed, _ := bank.GetResult()
+	block, _ := bank.ProcessBlock(signal)
+	for i := range freqs {
+		if streamed.DFT[i] != block.DFT[i] {
+			t.Errorf("bin %d: streaming and block results differ", i)
+		}
+	}
+	if math.Abs(block.Phases[0]) > 1e-9 {
+		t.Errorf("cosine phase should be 0, got %f", block.Phases[0])
+	}
+
+	if _, err := bank.ProcessBlock(signal[:10]); err == nil {
+		t.Error("expected error for wrong block length")
+	}
+	if _, err := NewGoertzelBank(nil, 8000, 64); err == nil {
+		t.Error("expected error for empty frequency list")
+	}
+	if _, err := NewGoertzelBank([]float64{5000}, 8000, 64); err == nil {
+		t.Error("expected error for frequency above Nyquist")
+	}
+}
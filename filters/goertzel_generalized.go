@@ -0,0 +1,174 @@
+package filters
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// GeneralizedGoertzelFilter реализует обобщенный алгоритм Герцеля для произвольной частоты
+// В отличие от GoertzelFilter, номер бина k = N*freq/samplingRate не округляется до целого,
+// поэтому частота анализируется точно. Результат - комплексное значение ДТФТ
+// X(w) = sum x[n] * e^(-jwn), n = 0..N-1, с корректной фазой относительно первого отсчета блока.
+type GeneralizedGoertzelFilter struct {
+	freq         float64    // Анализируемая частота (Гц)
+	samplingRate float64    // Частота дискретизации (Гц)
+	k            float64    // Дробный номер бина
+	w            float64    // Нормированная круговая частота 2*pi*k/N
+	coeff        float64    // Коэффициент рекуррентной формулы 2*cos(w)
+	expW         complex128 // e^(-jw)
+	phaseCorr    complex128 // Фазовая поправка e^(-jw(N-1))
+	q1           float64    // Состояние q[n-1]
+	q2           float64    // Состояние q[n-2]
+	n            int        // Текущий отсчет
+	totalN       int        // Полное количество выборок для анализа
+}
+
+// NewGeneralizedGoertzelFilter создает обобщенный фильтр Герцеля
+// freq: анализируемая частота в Гц (0 < freq < samplingRate/2), не обязательно кратная samplingRate/totalN
+func NewGeneralizedGoertzelFilter(freq, samplingRate float64, totalN int) (*GeneralizedGoertzelFilter, error) {
+	if freq <= 0 {
+		return nil, &InvalidParameterError{Param: "freq", Value: freq, Reason: "frequency must be positive"}
+	}
+	if samplingRate <= 0 {
+		return nil, &InvalidParameterError{Param: "samplingRate", Value: samplingRate, Reason: "sampling rate must be positive"}
+	}
+	if totalN <= 0 {
+		return nil, &InvalidParameterError{Param: "totalN", Value: float64(totalN), Reason: "total samples must be positive"}
+	}
+	if freq >= samplingRate/2 {
+		return nil, &InvalidParameterError{
+			Param:  "freq",
+			Value:  freq,
+			Reason: "frequency must be less than Nyquist frequency (samplingRate/2)",
+		}
+	}
+
+	k := float64(totalN) * freq / samplingRate
+	w := 2 * math.Pi * freq / samplingRate
+
+	return &GeneralizedGoertzelFilter{
+		freq:         freq,
+		samplingRate: samplingRate,
+		k:            k,
+		w:            w,
+		coeff:        2 * math.Cos(w),
+		expW:         cmplx.Exp(complex(0, -w)),
+		phaseCorr:    cmplx.Exp(complex(0, -w*float64(totalN-1))),
+		totalN:       totalN,
+	}, nil
+}
+
+// Process обрабатывает одно значение сигнала
+func (gf *GeneralizedGoertzelFilter) Process(input float64) error {
+	if gf == nil {
+		return &InvalidStateError{Reason: "filter is not initialized"}
+	}
+	if gf.n >= gf.totalN {
+		return &InvalidStateError{Reason: "all samples have already been processed"}
+	}
+
+	q0 := input + gf.coeff*gf.q1 - gf.q2
+	gf.q2 = gf.q1
+	gf.q1 = q0
+	gf.n++
+
+	return nil
+}
+
+// ProcessBlock обрабатывает блок отсчетов
+func (gf *GeneralizedGoertzelFilter) ProcessBlock(samples []float64) error {
+	for _, x := range samples {
+		if err := gf.Process(x); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reset сбрасывает состояние фильтра для нового расчета
+func (gf *GeneralizedGoertzelFilter) Reset() error {
+	if gf == nil {
+		return &InvalidStateError{Reason: "filter is not initialized"}
+	}
+	gf.q1 = 0
+	gf.q2 = 0
+	gf.n = 0
+	return nil
+}
+
+// GetDFT возвращает комплексное значение спектра на анализируемой частоте
+// Доступно только после обработки всех totalN отсчетов.
+func (gf *GeneralizedGoertzelFilter) GetDFT() (complex128, error) {
+	if gf == nil {
+		return 0, &InvalidStateError{Reason: "filter is not initialized"}
+	}
+	if gf.n < gf.totalN {
+		return 0, &InvalidStateError{Reason: "not all samples have been processed yet"}
+	}
+
+	// y = q[N-1] - e^(-jw) * q[N-2] = sum x[n] * e^(jw(N-1-n))
+	y := complex(gf.q1, 0) - gf.expW*complex(gf.q2, 0)
+
+	// Поворот фазы к началу блока: X(w) = e^(-jw(N-1)) * y
+	return y * gf.phaseCorr, nil
+}
+
+// GetMagnitude возвращает амплитуду синусоиды на анализируемой частоте (2|X|/N)
+func (gf *GeneralizedGoertzelFilter) GetMagnitude() (float64, error) {
+	x, err := gf.GetDFT()
+	if err != nil {
+		return 0, err
+	}
+	return 2 * cmplx.Abs(x) / float64(gf.totalN), nil
+}
+
+// GetPhase возвращает фазу компоненты на анализируемой частоте в радианах [-π, π]
+// Для сигнала A*cos(wn + φ) возвращается φ.
+func (gf *GeneralizedGoertzelFilter) GetPhase() (float64, error) {
+	x, err := gf.GetDFT()
+	if err != nil {
+		return 0, err
+	}
+	return cmplx.Phase(x), nil
+}
+
+// GetPower возвращает мощность сигнала на анализируемой частоте
+func (gf *GeneralizedGoertzelFilter) GetPower() (float64, error) {
+	magnitude, err := gf.GetMagnitude()
+	if err != nil {
+		return 0, err
+	}
+	return magnitude * magnitude / 2, nil
+}
+
+// IsComplete возвращает true, если обработаны все выборки
+func (gf *GeneralizedGoertzelFilter) IsComplete() bool {
+	if gf == nil {
+		return false
+	}
+	return gf.n >= gf.totalN
+}
+
+// GetProcessedCount возвращает количество обработанных отсчетов
+func (gf *GeneralizedGoertzelFilter) GetProcessedCount() int {
+	if gf == nil {
+		return 0
+	}
+	return gf.n
+}
+
+// GetTargetFrequency возвращает анализируемую частоту (без округления)
+func (gf *GeneralizedGoertzelFilter) GetTargetFrequency() float64 {
+	if gf == nil {
+		return 0
+	}
+	return gf.freq
+}
+
+// GetBin возвращает дробный номер бина k = N*freq/samplingRate
+func (gf *GeneralizedGoertzelFilter) GetBin() float64 {
+	if gf == nil {
+		return 0
+	}
+	return gf.k
+}
@@ -0,0 +1,146 @@
+package filters
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// TestGeneralizedGoertzelMatchesDTFT сравнивает результат с прямым вычислением ДТФТ
+func TestGeneralizedGoertzelMatchesDTFT(t *testing.T) {
+	samplingRate := 8000.0
+	totalN := 205
+
+	signal := make([]float64, totalN)
+	for i := range signal {
+		ti := float64(i) / samplingRate
+		signal[i] = math.Cos(2*math.Pi*697*ti+0.3) + 0.5*math.Sin(2*math.Pi*1209*ti)
+	}
+
+	for _, freq := range []float64{697, 770.5, 1209, 1633.3} {
+		gf, err := NewGeneralizedGoertzelFilter(freq, samplingRate, totalN)
+		if err != nil {
+			t.Fatalf("failed to create filter: %v", err)
+		}
+		if err := gf.ProcessBlock(signal); err != nil {
+			t.Fatalf("ProcessBlock failed: %v", err)
+		}
+
+		got, err := gf.GetDFT()
+		if err != nil {
+			t.Fatalf("GetDFT failed: %v", err)
+		}
+
+		var want complex128
+		w := 2 * math.Pi * freq / samplingRate
+		for n, x := range signal {
+			want += complex(x, 0) * cmplx.Exp(complex(0, -w*float64(n)))
+		}
+
+		if cmplx.Abs(got-want) > 1e-9*cmplx.Abs(want)+1e-9 {
+			t.Errorf("freq %.1f: DFT = %v, want %v", freq, got, want)
+		}
+	}
+}
+
+// TestGeneralizedGoertzelExactFrequency проверяет измерение тона вне сетки бинов
+func TestGeneralizedGoertzelExactFrequency(t *testing.T) {
+	samplingRate := 8000.0
+	totalN := 205
+	freq := 697.0
+
+	signal := make([]float64, totalN)
+	for i := range signal {
+		signal[i] = math.Cos(2 * math.Pi * freq * float64(i) / samplingRate)
+	}
+
+	gf, _ := NewGeneralizedGoertzelFilter(freq, samplingRate, totalN)
+	if math.Abs(gf.GetTargetFrequency()-freq) > 1e-12 {
+		t.Errorf("target frequency should be exactly %f, got %f", freq, gf.GetTargetFrequency())
+	}
+	if math.Abs(gf.GetBin()-17.860625) > 1e-9 {
+		t.Errorf("expected fractional bin 17.860625, got %f", gf.GetBin())
+	}
+	_ = gf.ProcessBlock(signal)
+	generalized, _ := gf.GetMagnitude()
+
+	// Классический фильтр округляет бин до 18 (702.4 Гц) и занижает амплитуду
+	classic, _ := NewGoertzelFilter(freq, samplingRate, totalN)
+	for _, x := range signal {
+		_ = classic.Process(x)
+	}
+	rounded, _ := classic.GetMagnitude()
+
+	if math.Abs(generalized-1) > 0.02 {
+		t.Errorf("generalized magnitude %f, want ~1", generalized)
+	}
+	if math.Abs(generalized-1) >= math.Abs(rounded-1) {
+		t.Errorf("generalized error %f should be smaller than integer-bin error %f",
+			math.Abs(generalized-1), math.Abs(rounded-1))
+	}
+}
+
+// TestGeneralizedGoertzelPhase проверяет измерение фазовых соотношений между тонами
+func TestGeneralizedGoertzelPhase(t *testing.T) {
+	samplingRate := 8000.0
+	totalN := 400
+	f1, f2 := 697.0, 1336.0
+	phi1, phi2 := 0.4, -1.1
+
+	signal := make([]float64, totalN)
+	for i := range signal {
+		ti := float64(i) / samplingRate
+		signal[i] = math.Cos(2*math.Pi*f1*ti+phi1) + math.Cos(2*math.Pi*f2*ti+phi2)
+	}
+
+	measure := func(freq float64) float64 {
+		gf, err := NewGeneralizedGoertzelFilter(freq, samplingRate, totalN)
+		if err != nil {
+			t.Fatalf("failed to create filter: %v", err)
+		}
+		_ = gf.ProcessBlock(signal)
+		phase, err := gf.GetPhase()
+		if err != nil {
+			t.Fatalf("GetPhase failed: %v", err)
+		}
+		return phase
+	}
+
+	p1, p2 := measure(f1), measure(f2)
+	if math.Abs(p1-phi1) > 0.02 {
+		t.Errorf("phase at %.0f Hz = %f, want %f", f1, p1, phi1)
+	}
+	if math.Abs(p2-phi2) > 0.02 {
+		t.Errorf("phase at %.0f Hz = %f, want %f", f2, p2, phi2)
+	}
+}
+
+// TestGeneralizedGoertzelState проверяет обработку состояний и ошибок
+func TestGeneralizedGoertzelState(t *testing.T) {
+	gf, err := NewGeneralizedGoertzelFilter(1000, 8000, 4)
+	if err != nil {
+		t.Fatalf("failed to create filter: %v", err)
+	}
+
+	if _, err := gf.GetDFT(); err == nil {
+		t.Error("expected error before all samples are processed")
+	}
+	_ = gf.ProcessBlock([]float64{1, 0, -1, 0})
+	if !gf.IsComplete() {
+		t.Error("filter should be complete")
+	}
+	if err := gf.Process(1); err == nil {
+		t.Error("expected error when processing beyond totalN")
+	}
+	_ = gf.Reset()
+	if gf.GetProcessedCount() != 0 {
+		t.Error("processed count should be zero after reset")
+	}
+
+	if _, err := NewGeneralizedGoertzelFilter(4000, 8000, 100); err == nil {
+		t.Error("expected error for frequency at Nyquist")
+	}
+	if _, err := NewGeneralizedGoertzelFilter(1000, 8000, 0); err == nil {
+		t.Error("expected error for zero block length")
+	}
+}
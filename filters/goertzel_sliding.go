@@ -0,0 +1,171 @@
+package filters
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// SlidingGoertzelFilter реализует скользящий фильтр Герцеля (однобиновое скользящее ДПФ)
+// В отличие от GoertzelFilter, который обрабатывает непересекающиеся блоки и требует Reset,
+// значение спектра на заданной частоте по последним windowSize отсчетам обновляется
+// на каждом отсчете за O(1) операций:
+// S[n] = x[n] + r*e^(jw)*S[n-1] - r^N*e^(jwN)*x[n-N]
+// Коэффициент затухания r < 1 сдвигает полюс внутрь единичной окружности,
+// благодаря чему накопленные ошибки округления затухают и фильтр остается устойчивым.
+type SlidingGoertzelFilter struct {
+	freq       float64    // Анализируемая частота (Гц)
+	w          float64    // Нормированная круговая частота
+	damping    float64    // Коэффициент затухания r (0 < r <= 1)
+	rot        complex128 // r*e^(jw)
+	combCoeff  complex128 // r^N*e^(jwN)
+	phaseCorr  complex128 // e^(-jw(N-1)) - приведение фазы к началу окна
+	normFactor float64    // Нормировка амплитуды: 2 / sum r^m
+
+	state  complex128 // Текущее состояние S[n]
+	buffer []float64  // Кольцевой буфер последних N отсчетов
+	pos    int        // Позиция самого старого отсчета
+	count  int        // Количество обработанных отсчетов (до заполнения окна)
+
+	sinceOutput int // Отсчеты после заполнения окна (для шага выдачи в ProcessBlock)
+}
+
+// NewSlidingGoertzelFilter создает скользящий фильтр Герцеля
+// freq: анализируемая частота в Гц (не обязательно кратная samplingRate/windowSize)
+// windowSize: длина окна анализа N
+// damping: коэффициент затухания r (0 < r <= 1), например 0.9999; при r = 1 затухания нет
+func NewSlidingGoertzelFilter(freq, samplingRate float64, windowSize int, damping float64) (*SlidingGoertzelFilter, error) {
+	if freq <= 0 {
+		return nil, &InvalidParameterError{Param: "freq", Value: freq, Reason: "frequency must be positive"}
+	}
+	if samplingRate <= 0 {
+		return nil, &InvalidParameterError{Param: "samplingRate", Value: samplingRate, Reason: "sampling rate must be positive"}
+	}
+	if freq >= samplingRate/2 {
+		return nil, &InvalidParameterError{
+			Param:  "freq",
+			Value:  freq,
+			Reason: "frequency must be less than Nyquist frequency (samplingRate/2)",
+		}
+	}
+	if windowSize <= 0 {
+		return nil, &InvalidParameterError{Param: "windowSize", Value: float64(windowSize), Reason: "window size must be positive"}
+	}
+	if damping <= 0 || damping > 1 {
+		return nil, &InvalidParameterError{Param: "damping", Value: damping, Reason: "damping must be in (0, 1]"}
+	}
+
+	n := float64(windowSize)
+	w := 2 * math.Pi * freq / samplingRate
+	rN := math.Pow(damping, n)
+
+	// Сумма весов sum_{m=0}^{N-1} r^m
+	weightSum := n
+	if damping < 1 {
+		weightSum = (1 - rN) / (1 - damping)
+	}
+
+	return &SlidingGoertzelFilter{
+		freq:       freq,
+		w:          w,
+		damping:    damping,
+		rot:        complex(damping, 0) * cmplx.Exp(complex(0, w)),
+		combCoeff:  complex(rN, 0) * cmplx.Exp(complex(0, w*n)),
+		phaseCorr:  cmplx.Exp(complex(0, -w*(n-1))),
+		normFactor: 2 / weightSum,
+		buffer:     make([]float64, windowSize),
+	}, nil
+}
+
+// Tick обрабатывает один отсчет и возвращает значение ДПФ по последним N отсчетам
+// (фаза отсчитывается от самого старого отсчета окна, как у обычного ДПФ)
+func (sg *SlidingGoertzelFilter) Tick(input float64) complex128 {
+	oldest := sg.buffer[sg.pos]
+	sg.buffer[sg.pos] = input
+	sg.pos = (sg.pos + 1) % len(sg.buffer)
+	if sg.count < len(sg.buffer) {
+		sg.count++
+	}
+
+	sg.state = complex(input, 0) + sg.rot*sg.state - sg.combCoeff*complex(oldest, 0)
+
+	return sg.state * sg.phaseCorr
+}
+
+// ProcessBlock обрабатывает блок отсчетов и возвращает значения ДПФ с шагом hop
+// Результаты выдаются только после заполнения окна.
+func (sg *SlidingGoertzelFilter) ProcessBlock(samples []float64, hop int) []complex128 {
+	if hop < 1 {
+		hop = 1
+	}
+
+	results := make([]complex128, 0, len(samples)/hop+1)
+	for _, x := range samples {
+		value := sg.Tick(x)
+		if !sg.IsReady() {
+			continue
+		}
+		if sg.sinceOutput%hop == 0 {
+			results = append(results, value)
+		}
+		sg.sinceOutput++
+	}
+	return results
+}
+
+// GetDFT возвращает текущее значение ДПФ по последним N отсчетам
+func (sg *SlidingGoertzelFilter) GetDFT() complex128 {
+	return sg.state * sg.phaseCorr
+}
+
+// GetMagnitude возвращает амплитуду тона на анализируемой частоте
+func (sg *SlidingGoertzelFilter) GetMagnitude() float64 {
+	return cmplx.Abs(sg.state) * sg.normFactor
+}
+
+// GetPower возвращает мощность тона на анализируемой частоте
+func (sg *SlidingGoertzelFilter) GetPower() float64 {
+	magnitude := sg.GetMagnitude()
+	return magnitude * magnitude / 2
+}
+
+// GetPhase возвращает фазу ДПФ относительно начала окна [-π, π]
+func (sg *SlidingGoertzelFilter) GetPhase() float64 {
+	return cmplx.Phase(sg.GetDFT())
+}
+
+// GetInstantaneousPhase возвращает фазу тона на последнем отсчете [-π, π]
+// Для сигнала A*cos(wn + φ) это значение w*n + φ, приведенное к [-π, π].
+func (sg *SlidingGoertzelFilter) GetInstantaneousPhase() float64 {
+	return cmplx.Phase(sg.state)
+}
+
+// Detect возвращает true, если окно заполнено и амплитуда тона не меньше порога
+func (sg *SlidingGoertzelFilter) Detect(threshold float64) bool {
+	return sg.IsReady() && sg.GetMagnitude() >= threshold
+}
+
+// IsReady возвращает true, если окно анализа полностью заполнено
+func (sg *SlidingGoertzelFilter) IsReady() bool {
+	return sg.count >= len(sg.buffer)
+}
+
+// Reset сбрасывает состояние фильтра
+func (sg *SlidingGoertzelFilter) Reset() {
+	for i := range sg.buffer {
+		sg.buffer[i] = 0
+	}
+	sg.state = 0
+	sg.pos = 0
+	sg.count = 0
+	sg.sinceOutput = 0
+}
+
+// GetTargetFrequency возвращает анализируемую частоту
+func (sg *SlidingGoertzelFilter) GetTargetFrequency() float64 {
+	return sg.freq
+}
+
+// GetWindowSize возвращает длину окна анализа
+func (sg *SlidingGoertzelFilter) GetWindowSize() int {
+	return len(sg.buffer)
+}
@@ -0,0 +1,150 @@
+package filters
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+// TestSlidingGoertzelMatchesBlock сравнивает скользящий результат с блочным обобщенным Герцелем
+func TestSlidingGoertzelMatchesBlock(t *testing.T) {
+	samplingRate := 8000.0
+	windowSize := 205
+	freq := 697.0
+
+	rng := rand.New(rand.NewSource(9))
+	signal := make([]float64, 1000)
+	for i := range signal {
+		signal[i] = math.Cos(2*math.Pi*freq*float64(i)/samplingRate+0.7) + 0.1*rng.NormFloat64()
+	}
+
+	sg, err := NewSlidingGoertzelFilter(freq, samplingRate, windowSize, 1)
+	if err != nil {
+		t.Fatalf("failed to create filter: %v", err)
+	}
+
+	for n, x := range signal {
+		got := sg.Tick(x)
+		if n+1 < windowSize {
+			if sg.IsReady() {
+				t.Fatal("filter should not be ready before the window is filled")
+			}
+			continue
+		}
+
+		block, _ := NewGeneralizedGoertzelFilter(freq, samplingRate, windowSize)
+		_ = block.ProcessBlock(signal[n+1-windowSize : n+1])
+		want, _ := block.GetDFT()
+
+		if cmplx.Abs(got-want) > 1e-8 {
+			t.Fatalf("sample %d: sliding DFT %v, block DFT %v", n, got, want)
+		}
+	}
+}
+
+// TestSlidingGoertzelDampedStability проверяет устойчивость на длинном сигнале с затуханием
+func TestSlidingGoertzelDampedStability(t *testing.T) {
+	samplingRate := 8000.0
+	windowSize := 100
+	freq := 1000.0
+	damping := 0.9999
+
+	sg, err := NewSlidingGoertzelFilter(freq, samplingRate, windowSize, damping)
+	if err != nil {
+		t.Fatalf("failed to create filter: %v", err)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	history := make([]float64, 0, 1000000)
+	for n := 0; n < 1000000; n++ {
+		x := rng.NormFloat64() + math.Sin(2*math.Pi*freq*float64(n)/samplingRate)
+		history = append(history, x)
+		sg.Tick(x)
+	}
+
+	// Опорное значение: взвешенная сумма sum r^m * x[n-m] * e^(jwm) по последним N отсчетам
+	w := 2 * math.Pi * freq / samplingRate
+	var ref complex128
+	last := len(history) - 1
+	for m := 0; m < windowSize; m++ {
+		ref += complex(math.Pow(damping, float64(m))*history[last-m], 0) * cmplx.Exp(complex(0, w*float64(m)))
+	}
+	ref *= cmplx.Exp(complex(0, -w*float64(windowSize-1)))
+
+	if cmplx.Abs(sg.GetDFT()-ref) > 1e-6 {
+		t.Errorf("accumulated error too large: %v vs %v", sg.GetDFT(), ref)
+	}
+	if math.Abs(sg.GetMagnitude()-1) > 0.3 {
+		t.Errorf("tone magnitude %f, want ~1", sg.GetMagnitude())
+	}
+}
+
+// TestSlidingGoertzelToneDetection проверяет обнаружение включения и выключения тона
+func TestSlidingGoertzelToneDetection(t *testing.T) {
+	samplingRate := 8000.0
+	windowSize := 160 // 20 мс
+	freq := 1209.0
+
+	sg, _ := NewSlidingGoertzelFilter(freq, samplingRate, windowSize, 0.99999)
+
+	onAt, offAt := 800, 2400
+	detectedOn, detectedOff := -1, -1
+	for n := 0; n < 4000; n++ {
+		x := 0.0
+		if n >= onAt && n < offAt {
+			x = 0.5 * math.Sin(2*math.Pi*freq*float64(n)/samplingRate)
+		}
+		sg.Tick(x)
+		present := sg.Detect(0.25)
+		if present && detectedOn < 0 {
+			detectedOn = n
+		}
+		if !present && detectedOn >= 0 && detectedOff < 0 {
+			detectedOff = n
+		}
+	}
+
+	// Тон обнаруживается и пропадает не позднее чем через длину окна
+	if detectedOn < onAt || detectedOn > onAt+windowSize {
+		t.Errorf("tone onset detected at %d, want within [%d, %d]", detectedOn, onAt, onAt+windowSize)
+	}
+	if detectedOff < offAt || detectedOff > offAt+windowSize {
+		t.Errorf("tone end detected at %d, want within [%d, %d]", detectedOff, offAt, offAt+windowSize)
+	}
+}
+
+// TestSlidingGoertzelPhaseAndHop проверяет фазу и выдачу результатов с шагом
+func TestSlidingGoertzelPhaseAndHop(t *testing.T) {
+	samplingRate := 8000.0
+	freq := 1000.0
+	phi := 0.5
+	sg, _ := NewSlidingGoertzelFilter(freq, samplingRate, 64, 1)
+
+	signal := make([]float64, 640)
+	for i := range signal {
+		signal[i] = math.Cos(2*math.Pi*freq*float64(i)/samplingRate + phi)
+	}
+
+	results := sg.ProcessBlock(signal, 16)
+	// После заполнения окна (64 отсчета) остается 577 результатов, с шагом 16 - 37
+	if len(results) != 37 {
+		t.Errorf("expected 37 results, got %d", len(results))
+	}
+
+	// Мгновенная фаза на последнем отсчете: w*n + φ
+	n := float64(len(signal) - 1)
+	want := math.Remainder(2*math.Pi*freq*n/samplingRate+phi, 2*math.Pi)
+	if math.Abs(math.Remainder(sg.GetInstantaneousPhase()-want, 2*math.Pi)) > 1e-6 {
+		t.Errorf("instantaneous phase %f, want %f", sg.GetInstantaneousPhase(), want)
+	}
+
+	sg.Reset()
+	if sg.IsReady() || sg.GetMagnitude() != 0 {
+		t.Error("filter should be empty after reset")
+	}
+
+	if _, err := NewSlidingGoertzelFilter(1000, 8000, 64, 1.5); err == nil {
+		t.Error("expected error for damping above 1")
+	}
+}
@@ -0,0 +1,309 @@
+package filters
+
+import (
+	"math"
+
+	"github.com/Alexxtn105/dsp/windows"
+)
+
+// DesignHalfBand рассчитывает коэффициенты полуполосного КИХ-фильтра (частота среза fs/4)
+// Используется метод оконного sinc с окном Кайзера.
+// У полуполосного фильтра центральный коэффициент равен 0.5, а каждый второй коэффициент -
+// точно ноль, что позволяет почти вдвое сократить число умножений.
+// numTaps: число коэффициентов вида 4k+3 (3, 7, 11, 15, ...)
+// beta: параметр окна Кайзера (больше - сильнее подавление, шире переходная полоса)
+func DesignHalfBand(numTaps int, beta float64) ([]float64, error) {
+	if numTaps < 3 || (numTaps+1)%4 != 0 {
+		return nil, &InvalidParameterError{
+			Param:  "numTaps",
+			Value:  float64(numTaps),
+			Reason: "half-band filter length must be of the form 4k+3",
+		}
+	}
+	if beta < 0 {
+		return nil, &InvalidParameterError{Param: "beta", Value: beta, Reason: "Kaiser beta must be non-negative"}
+	}
+
+	center := numTaps / 2
+	ideal := make([]float64, numTaps)
+	for n := 0; n < numTaps; n++ {
+		k := n - center
+		switch {
+		case k == 0:
+			ideal[n] = 0.5
+		case k%2 == 0:
+			ideal[n] = 0
+		default:
+			ideal[n] = math.Sin(math.Pi*float64(k)/2) / (math.Pi * float64(k))
+		}
+	}
+
+	coeffs := windows.ApplyKaiserWindow(ideal, beta)
+
+	// Нормировка на единичное усиление на нулевой частоте с сохранением центрального коэффициента 0.5:
+	// сумма боковых коэффициентов должна быть равна 0.5
+	var side float64
+	for n, h := range coeffs {
+		if n != center {
+			side += h
+		}
+	}
+	for n := range coeffs {
+		if n != center {
+			coeffs[n] *= 0.5 / side
+		}
+	}
+	coeffs[center] = 0.5
+
+	return coeffs, nil
+}
+
+// HalfBandDecimator реализует прореживание в 2 раза полуполосным фильтром
+// Выход вычисляется только на каждом втором такте, нулевые коэффициенты пропускаются,
+// а симметрия позволяет делать одно умножение на пару отсчетов.
+type HalfBandDecimator struct {
+	coeffs []float64 // Полный набор коэффициентов (для справки)
+	taps   []float64 // Уникальные ненулевые боковые коэффициенты h[c-1], h[c-3], ...
+	center int       // Индекс центрального коэффициента
+
+	buffer []float64 // Двойной кольцевой буфер для непрерывного чтения окна
+	pos    int       // Позиция записи
+	phase  int       // Фаза прореживания
+}
+
+// NewHalfBandDecimator создает полуполосный дециматор на 2
+func NewHalfBandDecimator(numTaps int, beta float64) (*HalfBandDecimator, error) {
+	coeffs, err := DesignHalfBand(numTaps, beta)
+	if err != nil {
+		return nil, err
+	}
+	return newHalfBandDecimatorFromCoeffs(coeffs), nil
+}
+
+// newHalfBandDecimatorFromCoeffs создает дециматор из готовых полуполосных коэффициентов
+func newHalfBandDecimatorFromCoeffs(coeffs []float64) *HalfBandDecimator {
+	n := len(coeffs)
+	center := n / 2
+
+	taps := make([]float64, 0, (center+1)/2)
+	for d := 1; d <= center; d += 2 {
+		taps = append(taps, coeffs[center-d])
+	}
+
+	return &HalfBandDecimator{
+		coeffs: append([]float64{}, coeffs...),
+		taps:   taps,
+		center: center,
+		buffer: make([]float64, 2*n),
+	}
+}
+
+// Tick обрабатывает один входной отсчет
+// Возвращает выходной отсчет и true на каждом втором такте
+func (hb *HalfBandDecimator) Tick(input float64) (float64, bool) {
+	n := len(hb.coeffs)
+
+	// Запись в двойной буфер: окно [pos, pos+n) всегда непрерывно, от старых отсчетов к новым
+	hb.buffer[hb.pos] = input
+	hb.buffer[hb.pos+n] = input
+	hb.pos = (hb.pos + 1) % n
+
+	hb.phase ^= 1
+	if hb.phase != 0 {
+		return 0, false
+	}
+
+	// window[n-1] - самый новый отсчет, window[0] - самый старый
+	window := hb.buffer[hb.pos : hb.pos+n]
+	mid := n - 1 - hb.center
+
+	output := 0.5 * window[mid]
+	for j, h := range hb.taps {
+		d := 2*j + 1
+		output += h * (window[mid-d] + window[mid+d])
+	}
+
+	return output, true
+}
+
+// Process обрабатывает срез входных отсчетов и возвращает прореженный выход
+func (hb *HalfBandDecimator) Process(input []float64) []float64 {
+	output := make([]float64, 0, len(input)/2+1)
+	for _, x := range input {
+		if y, ok := hb.Tick(x); ok {
+			output = append(output, y)
+		}
+	}
+	return output
+}
+
+// Reset сбрасывает состояние дециматора
+func (hb *HalfBandDecimator) Reset() {
+	for i := range hb.buffer {
+		hb.buffer[i] = 0
+	}
+	hb.pos = 0
+	hb.phase = 0
+}
+
+// GetCoefficients возвращает копию коэффициентов фильтра
+func (hb *HalfBandDecimator) GetCoefficients() []float64 {
+	return append([]float64{}, hb.coeffs...)
+}
+
+// GetMultiplyCount возвращает число умножений на один выходной отсчет
+func (hb *HalfBandDecimator) GetMultiplyCount() int {
+	return len(hb.taps) + 1
+}
+
+// HalfBandInterpolator реализует интерполяцию в 2 раза полуполосным фильтром
+// Четные выходные отсчеты вычисляются по ненулевым боковым коэффициентам с учетом симметрии,
+// нечетные - это просто задержанный входной отсчет (центральный коэффициент).
+type HalfBandInterpolator struct {
+	coeffs []float64 // Полный набор коэффициентов
+	taps   []float64 // Коэффициенты четной полифазной ветви h[0], h[2], ..., h[c-1]
+
+	buffer []float64 // Двойной кольцевой буфер входных отсчетов
+	pos    int       // Позиция записи
+}
+
+// NewHalfBandInterpolator создает полуполосный интерполятор на 2
+func NewHalfBandInterpolator(numTaps int, beta float64) (*HalfBandInterpolator, error) {
+	coeffs, err := DesignHalfBand(numTaps, beta)
+	if err != nil {
+		return nil, err
+	}
+
+	center := numTaps / 2
+	taps := make([]float64, 0, (center+1)/2)
+	for k := 0; k < center; k += 2 {
+		taps = append(taps, coeffs[k])
+	}
+
+	// Длина полифазной ветви: (numTaps+1)/2 входных отсчетов
+	branch := (numTaps + 1) / 2
+
+	return &HalfBandInterpolator{
+		coeffs: coeffs,
+		taps:   taps,
+		buffer: make([]float64, 2*branch),
+	}, nil
+}
+
+// Tick обрабатывает один входной отсчет и возвращает два выходных отсчета
+// Усиление нормировано так, что постоянная составляющая сохраняется
+func (hb *HalfBandInterpolator) Tick(input float64) []float64 {
+	branch := len(hb.buffer) / 2
+
+	hb.buffer[hb.pos] = input
+	hb.buffer[hb.pos+branch] = input
+	hb.pos = (hb.pos + 1) % branch
+
+	// window[branch-1] - самый новый отсчет
+	window := hb.buffer[hb.pos : hb.pos+branch]
+	newest := branch - 1
+
+	// Четная ветвь: y[2n] = 2 * sum h[2i] * x[n-i], коэффициенты симметричны
+	var even float64
+	for i, h := range hb.taps {
+		even += h * (window[newest-i] + window[i])
+	}
+
+	// Нечетная ветвь: y[2n+1] = 2 * 0.5 * x[n-(c-1)/2]
+	center := len(hb.coeffs) / 2
+	odd := window[newest-(center-1)/2]
+
+	return []float64{2 * even, odd}
+}
+
+// Process обрабатывает срез входных отсчетов и возвращает интерполированный выход
+func (hb *HalfBandInterpolator) Process(input []float64) []float64 {
+	output := make([]float64, 0, 2*len(input))
+	for _, x := range input {
+		output = append(output, hb.Tick(x)...)
+	}
+	return output
+}
+
+// Reset сбрасывает состояние интерполятора
+func (hb *HalfBandInterpolator) Reset() {
+	for i := range hb.buffer {
+		hb.buffer[i] = 0
+	}
+	hb.pos = 0
+}
+
+// GetCoefficients возвращает копию коэффициентов фильтра
+func (hb *HalfBandInterpolator) GetCoefficients() []float64 {
+	return append([]float64{}, hb.coeffs...)
+}
+
+// HalfBandDecimatorChain реализует многокаскадное прореживание в 2^k раз
+// последовательностью полуполосных дециматоров
+type HalfBandDecimatorChain struct {
+	stages []*HalfBandDecimator // Каскады прореживания на 2
+	ratio  int                  // Общий коэффициент прореживания
+}
+
+// NewHalfBandDecimatorChain создает многокаскадный дециматор
+// ratio: общий коэффициент прореживания (степень двойки, >= 2)
+// numTaps, beta: параметры полуполосного фильтра каждого каскада
+func NewHalfBandDecimatorChain(ratio, numTaps int, beta float64) (*HalfBandDecimatorChain, error) {
+	if ratio < 2 || ratio&(ratio-1) != 0 {
+		return nil, &InvalidParameterError{Param: "ratio", Value: float64(ratio), Reason: "ratio must be a power of 2 and at least 2"}
+	}
+
+	coeffs, err := DesignHalfBand(numTaps, beta)
+	if err != nil {
+		return nil, err
+	}
+
+	chain := &HalfBandDecimatorChain{ratio: ratio}
+	for r := ratio; r > 1; r >>= 1 {
+		chain.stages = append(chain.stages, newHalfBandDecimatorFromCoeffs(coeffs))
+	}
+
+	return chain, nil
+}
+
+// Tick обрабатывает один входной отсчет
+// Возвращает выходной отсчет и true, когда последний каскад сформировал отсчет
+func (c *HalfBandDecimatorChain) Tick(input float64) (float64, bool) {
+	v := input
+	for _, stage := range c.stages {
+		y, ok := stage.Tick(v)
+		if !ok {
+			return 0, false
+		}
+		v = y
+	}
+	return v, true
+}
+
+// Process обрабатывает срез входных отсчетов и возвращает прореженный выход
+func (c *HalfBandDecimatorChain) Process(input []float64) []float64 {
+	output := make([]float64, 0, len(input)/c.ratio+1)
+	for _, x := range input {
+		if y, ok := c.Tick(x); ok {
+			output = append(output, y)
+		}
+	}
+	return output
+}
+
+// Reset сбрасывает состояние всех каскадов
+func (c *HalfBandDecimatorChain) Reset() {
+	for _, stage := range c.stages {
+		stage.Reset()
+	}
+}
+
+// GetRatio возвращает общий коэффициент прореживания
+func (c *HalfBandDecimatorChain) GetRatio() int {
+	return c.ratio
+}
+
+// GetStageCount возвращает количество каскадов
+func (c *HalfBandDecimatorChain) GetStageCount() int {
+	return len(c.stages)
+}
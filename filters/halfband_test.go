@@ -0,0 +1,149 @@
+package filters
+
+import (
+	"math"
+	"testing"
+)
+
+// TestDesignHalfBand проверяет структуру коэффициентов полуполосного фильтра
+func TestDesignHalfBand(t *testing.T) {
+	coeffs, err := DesignHalfBand(31, 8.0)
+	if err != nil {
+		t.Fatalf("failed to design half-band filter: %v", err)
+	}
+
+	center := len(coeffs) / 2
+	if coeffs[center] != 0.5 {
+		t.Errorf("center coefficient should be 0.5, got %f", coeffs[center])
+	}
+
+	var sum float64
+	for n, h := range coeffs {
+		sum += h
+		k := n - center
+		if k != 0 && k%2 == 0 && h != 0 {
+			t.Errorf("coefficient %d should be exactly zero, got %g", n, h)
+		}
+		if math.Abs(h-coeffs[len(coeffs)-1-n]) > 1e-15 {
+			t.Errorf("coefficients are not symmetric at %d", n)
+		}
+	}
+	if math.Abs(sum-1) > 1e-12 {
+		t.Errorf("DC gain should be 1, got %f", sum)
+	}
+
+	for _, bad := range []int{0, 1, 4, 5, 13} {
+		if _, err := DesignHalfBand(bad, 8.0); err == nil {
+			t.Errorf("expected error for numTaps=%d", bad)
+		}
+	}
+}
+
+// TestHalfBandDecimatorMatchesFIR сравнивает дециматор с полным КИХ-фильтром и прореживанием
+func TestHalfBandDecimatorMatchesFIR(t *testing.T) {
+	hb, err := NewHalfBandDecimator(23, 6.0)
+	if err != nil {
+		t.Fatalf("failed to create decimator: %v", err)
+	}
+	fir := NewFIRFilter(hb.GetCoefficients())
+
+	input := make([]float64, 300)
+	for i := range input {
+		input[i] = math.Sin(0.07*float64(i)) + 0.3*math.Cos(1.9*float64(i))
+	}
+
+	full := make([]float64, len(input))
+	for i, x := range input {
+		full[i] = fir.Tick(x)
+	}
+
+	output := hb.Process(input)
+	if len(output) != len(input)/2 {
+		t.Fatalf("expected %d outputs, got %d", len(input)/2, len(output))
+	}
+	for m, y := range output {
+		if want := full[2*m+1]; math.Abs(y-want) > 1e-12 {
+			t.Fatalf("output[%d] = %f, want %f", m, y, want)
+		}
+	}
+
+	// Умножений примерно вдвое меньше, чем половина длины фильтра
+	if hb.GetMultiplyCount() != 7 {
+		t.Errorf("expected 7 multiplies per output, got %d", hb.GetMultiplyCount())
+	}
+}
+
+// TestHalfBandInterpolatorMatchesFIR сравнивает интерполятор со вставкой нулей и КИХ-фильтром
+func TestHalfBandInterpolatorMatchesFIR(t *testing.T) {
+	hb, err := NewHalfBandInterpolator(19, 6.0)
+	if err != nil {
+		t.Fatalf("failed to create interpolator: %v", err)
+	}
+
+	coeffs := hb.GetCoefficients()
+	for i := range coeffs {
+		coeffs[i] *= 2
+	}
+	fir := NewFIRFilter(coeffs)
+
+	input := make([]float64, 100)
+	for i := range input {
+		input[i] = math.Cos(0.3*float64(i)) - 0.5*math.Sin(0.11*float64(i))
+	}
+
+	output := hb.Process(input)
+	for i, x := range input {
+		for p := 0; p < 2; p++ {
+			u := 0.0
+			if p == 0 {
+				u = x
+			}
+			want := fir.Tick(u)
+			if got := output[2*i+p]; math.Abs(got-want) > 1e-12 {
+				t.Fatalf("output[%d] = %f, want %f", 2*i+p, got, want)
+			}
+		}
+	}
+}
+
+// TestHalfBandDecimatorChain проверяет многокаскадное прореживание
+func TestHalfBandDecimatorChain(t *testing.T) {
+	chain, err := NewHalfBandDecimatorChain(8, 31, 8.0)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	if chain.GetStageCount() != 3 {
+		t.Errorf("expected 3 stages, got %d", chain.GetStageCount())
+	}
+
+	measure := func(freq float64) float64 {
+		chain.Reset()
+		input := make([]float64, 8192)
+		for i := range input {
+			input[i] = math.Cos(2 * math.Pi * freq * float64(i))
+		}
+		output := chain.Process(input)
+		if len(output) != len(input)/8 {
+			t.Fatalf("expected %d outputs, got %d", len(input)/8, len(output))
+		}
+		var peak float64
+		for _, y := range output[len(output)/2:] {
+			peak = math.Max(peak, math.Abs(y))
+		}
+		return peak
+	}
+
+	// Полезный сигнал в полосе пропускания выходной частоты проходит без ослабления
+	if gain := measure(0.01); math.Abs(gain-1) > 0.01 {
+		t.Errorf("passband gain should be ~1, got %f", gain)
+	}
+
+	// Сигнал, который после прореживания попал бы в полосу пропускания, подавлен
+	if gain := measure(0.2); gain > 1e-3 {
+		t.Errorf("alias component should be suppressed, got gain %f", gain)
+	}
+
+	if _, err := NewHalfBandDecimatorChain(6, 31, 8.0); err == nil {
+		t.Error("expected error for non power-of-two ratio")
+	}
+}
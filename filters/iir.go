@@ -291,6 +291,26 @@ func (f *IIRFilter) GetACoeffs() []float64 {
 	return append([]float64{}, f.aCoeffs...)
 }
 
+// SetCoefficients заменяет коэффициенты фильтра без сброса буферов
+// Используется для фильтров с изменяющимися во времени параметрами.
+// Длины новых наборов должны совпадать с текущими, a[0] нормализуется к 1.
+func (f *IIRFilter) SetCoefficients(bCoeffs, aCoeffs []float64) {
+	if len(bCoeffs) != len(f.bCoeffs) || len(aCoeffs) != len(f.aCoeffs) {
+		panic("IIRFilter: coefficient count cannot change")
+	}
+	if aCoeffs[0] == 0 {
+		panic("IIRFilter: a[0] cannot be zero")
+	}
+
+	normalizer := aCoeffs[0]
+	for i := range bCoeffs {
+		f.bCoeffs[i] = bCoeffs[i] / normalizer
+	}
+	for i := range aCoeffs {
+		f.aCoeffs[i] = aCoeffs[i] / normalizer
+	}
+}
+
 // GetOrder возвращает порядок фильтра
 func (f *IIRFilter) GetOrder() int {
 	return f.order
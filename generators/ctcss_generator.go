@@ -0,0 +1,85 @@
+package generators
+
+import (
+	"fmt"
+	"math"
+)
+
+// CTCSSTones - стандартный ряд частот CTCSS (EIA/TIA-603), Гц
+var CTCSSTones = []float64{
+	67.0, 69.3, 71.9, 74.4, 77.0, 79.7, 82.5, 85.4, 88.5, 91.5,
+	94.8, 97.4, 100.0, 103.5, 107.2, 110.9, 114.8, 118.8, 123.0, 127.3,
+	131.8, 136.5, 141.3, 146.2, 151.4, 156.7, 159.8, 162.2, 165.5, 167.9,
+	171.3, 173.8, 177.3, 179.9, 183.5, 186.2, 189.9, 192.8, 196.6, 199.5,
+	203.5, 206.5, 210.7, 218.1, 225.7, 229.1, 233.6, 241.8, 250.3, 254.1,
+}
+
+// CTCSSGenerator генерирует субтональный сигнал CTCSS с необязательной речевой помехой
+type CTCSSGenerator struct {
+	SampleRate     float64 // Частота дискретизации в герцах
+	Frequency      float64 // Частота субтона в герцах
+	Amplitude      float64 // Амплитуда субтона
+	Duration       float64 // Длительность субтона в секундах
+	LeadingSilence float64 // Интервал без субтона перед ним в секундах
+	TrailingTime   float64 // Интервал без субтона после него в секундах
+	VoiceAmplitude float64 // Амплитуда имитации речи (набор тонов 300-3000 Гц) на всем интервале
+	NoiseAmplitude float64 // СКО аддитивного гауссова шума
+	Seed           int64   // Начальное значение генератора шума
+}
+
+// NewCTCSSGenerator создает генератор CTCSS (8 кГц, 1 с, амплитуда 0.1)
+func NewCTCSSGenerator(frequency float64) *CTCSSGenerator {
+	return &CTCSSGenerator{
+		SampleRate: 8000.0,
+		Frequency:  frequency,
+		Amplitude:  0.1,
+		Duration:   1.0,
+	}
+}
+
+// ctcssVoiceTones - частоты, относительные амплитуды и начальные фазы имитации речевой помехи
+var ctcssVoiceTones = [][3]float64{{310, 1, 0}, {520, 0.8, 1.1}, {870, 0.6, 2.3}, {1240, 0.4, 0.7}, {2230, 0.2, 4.2}}
+
+// Generate создает сигнал: пауза, субтон, пауза; речевая помеха и шум накладываются на весь интервал
+func (g *CTCSSGenerator) Generate() ([]float64, error) {
+	if g.SampleRate <= 0 {
+		return nil, fmt.Errorf("частота дискретизации должна быть положительной: %f", g.SampleRate)
+	}
+	if g.Frequency <= 0 || g.Frequency >= g.SampleRate/2 {
+		return nil, fmt.Errorf("частота субтона должна быть в диапазоне (0, %f): %f", g.SampleRate/2, g.Frequency)
+	}
+	if g.Duration < 0 || g.LeadingSilence < 0 || g.TrailingTime < 0 {
+		return nil, fmt.Errorf("длительности не могут быть отрицательными")
+	}
+	if g.NoiseAmplitude < 0 || g.VoiceAmplitude < 0 {
+		return nil, fmt.Errorf("уровни помех не могут быть отрицательными")
+	}
+
+	lead := int(math.Round(g.LeadingSilence * g.SampleRate))
+	tone := int(math.Round(g.Duration * g.SampleRate))
+	trail := int(math.Round(g.TrailingTime * g.SampleRate))
+	signal := make([]float64, lead+tone+trail)
+
+	w := 2 * math.Pi * g.Frequency / g.SampleRate
+	for n := 0; n < tone; n++ {
+		signal[lead+n] = g.Amplitude * math.Sin(w*float64(n))
+	}
+
+	if g.VoiceAmplitude > 0 {
+		for _, vt := range ctcssVoiceTones {
+			if vt[0] >= g.SampleRate/2 {
+				continue
+			}
+			wv := 2 * math.Pi * vt[0] / g.SampleRate
+			for n := range signal {
+				signal[n] += g.VoiceAmplitude * vt[1] * math.Sin(wv*float64(n)+vt[2])
+			}
+		}
+	}
+
+	if g.NoiseAmplitude > 0 {
+		addNoise(signal, g.NoiseAmplitude, g.Seed)
+	}
+
+	return signal, nil
+}
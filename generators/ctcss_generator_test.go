@@ -0,0 +1,36 @@
+package generators
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCTCSSGenerator(t *testing.T) {
+	gen := NewCTCSSGenerator(100.0)
+	gen.LeadingSilence = 0.5
+	signal, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if len(signal) != 12000 {
+		t.Fatalf("expected 12000 samples, got %d", len(signal))
+	}
+	for _, x := range signal[:4000] {
+		if x != 0 {
+			t.Fatal("leading interval should be silent")
+		}
+	}
+	if level := toneLevel(signal[4000:], 100, 8000); math.Abs(level-0.1) > 0.005 {
+		t.Errorf("tone level %f, want 0.1", level)
+	}
+
+	gen.VoiceAmplitude = 0.3
+	voiced, _ := gen.Generate()
+	if level := toneLevel(voiced[:4000], 310, 8000); math.Abs(level-0.3) > 0.02 {
+		t.Errorf("voice component level %f, want 0.3", level)
+	}
+
+	if _, err := NewCTCSSGenerator(5000).Generate(); err == nil {
+		t.Error("expected error for tone above Nyquist")
+	}
+}
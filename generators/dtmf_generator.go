@@ -0,0 +1,174 @@
+package generators
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// DTMFLowFrequencies - частоты нижней группы DTMF (строки клавиатуры), Гц
+var DTMFLowFrequencies = [4]float64{697, 770, 852, 941}
+
+// DTMFHighFrequencies - частоты верхней группы DTMF (столбцы клавиатуры), Гц
+var DTMFHighFrequencies = [4]float64{1209, 1336, 1477, 1633}
+
+// DTMFKeypad - раскладка клавиатуры DTMF: DTMFKeypad[строка][столбец]
+var DTMFKeypad = [4][4]rune{
+	{'1', '2', '3', 'A'},
+	{'4', '5', '6', 'B'},
+	{'7', '8', '9', 'C'},
+	{'*', '0', '#', 'D'},
+}
+
+// DTMFFrequencies возвращает пару частот (нижняя, верхняя) для символа DTMF
+func DTMFFrequencies(digit rune) (low, high float64, err error) {
+	for row := range DTMFKeypad {
+		for col, key := range DTMFKeypad[row] {
+			if key == digit {
+				return DTMFLowFrequencies[row], DTMFHighFrequencies[col], nil
+			}
+		}
+	}
+	return 0, 0, fmt.Errorf("неизвестный символ DTMF: %q", digit)
+}
+
+// DTMFGenerator генерирует последовательности сигналов DTMF для проверки декодеров
+// Позволяет моделировать перекос уровней, отклонение частот и шум, как в испытаниях по ITU-T Q.24
+type DTMFGenerator struct {
+	SampleRate         float64 // Частота дискретизации в герцах
+	Amplitude          float64 // Амплитуда тона нижней группы
+	ToneDuration       float64 // Длительность тона в секундах
+	PauseDuration      float64 // Длительность паузы после тона в секундах
+	LeadingSilence     float64 // Тишина перед первым тоном в секундах
+	TwistDB            float64 // Перекос: уровень верхней группы относительно нижней, дБ
+	FrequencyDeviation float64 // Относительное отклонение частот (например, 0.015 = +1.5%)
+	NoiseAmplitude     float64 // СКО аддитивного гауссова шума
+	Seed               int64   // Начальное значение генератора шума
+}
+
+// NewDTMFGenerator создает генератор DTMF с настройками по умолчанию
+// (8 кГц, тон 50 мс, пауза 50 мс, без перекоса и шума)
+func NewDTMFGenerator() *DTMFGenerator {
+	return &DTMFGenerator{
+		SampleRate:    8000.0,
+		Amplitude:     0.5,
+		ToneDuration:  0.050,
+		PauseDuration: 0.050,
+	}
+}
+
+// Generate создает сигнал для последовательности символов DTMF
+func (g *DTMFGenerator) Generate(digits string) ([]float64, error) {
+	pairs := make([][2]float64, 0, len(digits))
+	for _, d := range digits {
+		low, high, err := DTMFFrequencies(d)
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, [2]float64{low, high})
+	}
+
+	return generateTonePairs(pairs, tonePairParams{
+		sampleRate:     g.SampleRate,
+		amplitude:      g.Amplitude,
+		toneDuration:   g.ToneDuration,
+		pauseDuration:  g.PauseDuration,
+		leadingSilence: g.LeadingSilence,
+		twistDB:        g.TwistDB,
+		deviation:      g.FrequencyDeviation,
+		noiseAmplitude: g.NoiseAmplitude,
+		seed:           g.Seed,
+	})
+}
+
+// tonePairParams - общие параметры генерации последовательностей двухчастотных сигналов
+type tonePairParams struct {
+	sampleRate     float64
+	amplitude      float64
+	toneDuration   float64
+	pauseDuration  float64
+	leadingSilence float64
+	twistDB        float64
+	deviation      float64
+	noiseAmplitude float64
+	seed           int64
+	toneDurations  []float64 // Индивидуальные длительности посылок (если заданы, заменяют toneDuration)
+}
+
+// validate проверяет параметры генерации
+func (p tonePairParams) validate(maxFreq float64) error {
+	if p.sampleRate <= 0 {
+		return fmt.Errorf("частота дискретизации должна быть положительной: %f", p.sampleRate)
+	}
+	if p.amplitude <= 0 {
+		return fmt.Errorf("амплитуда должна быть положительной: %f", p.amplitude)
+	}
+	if p.toneDuration <= 0 {
+		return fmt.Errorf("длительность тона должна быть положительной: %f", p.toneDuration)
+	}
+	if p.pauseDuration < 0 || p.leadingSilence < 0 {
+		return fmt.Errorf("длительность паузы не может быть отрицательной")
+	}
+	for _, d := range p.toneDurations {
+		if d <= 0 {
+			return fmt.Errorf("длительность тона должна быть положительной: %f", d)
+		}
+	}
+	if p.noiseAmplitude < 0 {
+		return fmt.Errorf("уровень шума не может быть отрицательным: %f", p.noiseAmplitude)
+	}
+	if maxFreq*(1+math.Abs(p.deviation))*2 >= p.sampleRate {
+		return fmt.Errorf(
+			"нарушен критерий Найквиста: частота тона (%f Гц) должна быть меньше половины частоты дискретизации (%f Гц)",
+			maxFreq, p.sampleRate/2,
+		)
+	}
+	return nil
+}
+
+// generateTonePairs генерирует последовательность двухчастотных посылок с паузами
+// Первая частота пары имеет амплитуду amplitude, вторая - amplitude * 10^(twistDB/20)
+func generateTonePairs(pairs [][2]float64, p tonePairParams) ([]float64, error) {
+	var maxFreq float64
+	for _, pair := range pairs {
+		maxFreq = math.Max(maxFreq, math.Max(pair[0], pair[1]))
+	}
+	if err := p.validate(maxFreq); err != nil {
+		return nil, err
+	}
+
+	toneSamples := int(math.Round(p.toneDuration * p.sampleRate))
+	pauseSamples := int(math.Round(p.pauseDuration * p.sampleRate))
+	leadSamples := int(math.Round(p.leadingSilence * p.sampleRate))
+
+	signal := make([]float64, leadSamples, leadSamples+len(pairs)*(toneSamples+pauseSamples))
+	highAmplitude := p.amplitude * math.Pow(10, p.twistDB/20)
+	scale := 1 + p.deviation
+
+	for i, pair := range pairs {
+		length := toneSamples
+		if i < len(p.toneDurations) {
+			length = int(math.Round(p.toneDurations[i] * p.sampleRate))
+		}
+		w1 := 2 * math.Pi * pair[0] * scale / p.sampleRate
+		w2 := 2 * math.Pi * pair[1] * scale / p.sampleRate
+		for n := 0; n < length; n++ {
+			signal = append(signal, p.amplitude*math.Sin(w1*float64(n))+highAmplitude*math.Sin(w2*float64(n)))
+		}
+		signal = append(signal, make([]float64, pauseSamples)...)
+	}
+
+	if p.noiseAmplitude > 0 {
+		addNoise(signal, p.noiseAmplitude, p.seed)
+	}
+
+	return signal, nil
+}
+
+// addNoise добавляет к сигналу гауссов шум с СКО sigma (воспроизводимый для одного seed)
+func addNoise(signal []float64, sigma float64, seed int64) {
+	rng := rand.New(rand.NewSource(seed))
+	for i := range signal {
+		signal[i] += sigma * rng.NormFloat64()
+	}
+}
@@ -0,0 +1,104 @@
+package generators
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// toneLevel оценивает амплитуду составляющей заданной частоты по корреляции
+func toneLevel(signal []float64, freq, sampleRate float64) float64 {
+	var re, im float64
+	for n, x := range signal {
+		angle := 2 * math.Pi * freq * float64(n) / sampleRate
+		re += x * math.Cos(angle)
+		im += x * math.Sin(angle)
+	}
+	return 2 * math.Hypot(re, im) / float64(len(signal))
+}
+
+func TestDTMFFrequencies(t *testing.T) {
+	tests := []struct {
+		digit     rune
+		low, high float64
+	}{
+		{'1', 697, 1209},
+		{'5', 770, 1336},
+		{'9', 852, 1477},
+		{'0', 941, 1336},
+		{'#', 941, 1477},
+		{'D', 941, 1633},
+	}
+
+	for _, tt := range tests {
+		low, high, err := DTMFFrequencies(tt.digit)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", tt.digit, err)
+		}
+		if low != tt.low || high != tt.high {
+			t.Errorf("%q: got (%v, %v), want (%v, %v)", tt.digit, low, high, tt.low, tt.high)
+		}
+	}
+
+	if _, _, err := DTMFFrequencies('X'); err == nil {
+		t.Error("expected error for unknown digit")
+	}
+}
+
+func TestDTMFGenerator(t *testing.T) {
+	gen := NewDTMFGenerator()
+	gen.TwistDB = -4
+
+	signal, err := gen.Generate("58")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	// Две посылки по 50 мс тона и 50 мс паузы при 8 кГц
+	if len(signal) != 1600 {
+		t.Fatalf("expected 1600 samples, got %d", len(signal))
+	}
+
+	tone := signal[:400]
+	if level := toneLevel(tone, 770, 8000); math.Abs(level-0.5) > 0.02 {
+		t.Errorf("low tone level %f, want 0.5", level)
+	}
+	wantHigh := 0.5 * math.Pow(10, -4.0/20)
+	if level := toneLevel(tone, 1336, 8000); math.Abs(level-wantHigh) > 0.02 {
+		t.Errorf("high tone level %f, want %f", level, wantHigh)
+	}
+	for _, x := range signal[400:800] {
+		if x != 0 {
+			t.Fatal("pause should be silent without noise")
+		}
+	}
+
+	gen.NoiseAmplitude = 0.01
+	gen.Seed = 1
+	a, _ := gen.Generate("1")
+	b, _ := gen.Generate("1")
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatal("noise should be reproducible for the same seed")
+		}
+	}
+}
+
+func TestDTMFGeneratorErrors(t *testing.T) {
+	gen := NewDTMFGenerator()
+	if _, err := gen.Generate("12X"); err == nil {
+		t.Error("expected error for invalid digit")
+	}
+
+	gen.SampleRate = 2000
+	_, err := gen.Generate("1")
+	if err == nil || !strings.Contains(err.Error(), "Найквиста") {
+		t.Errorf("expected Nyquist error, got %v", err)
+	}
+
+	gen = NewDTMFGenerator()
+	gen.ToneDuration = 0
+	if _, err := gen.Generate("1"); err == nil {
+		t.Error("expected error for zero tone duration")
+	}
+}
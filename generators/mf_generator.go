@@ -0,0 +1,138 @@
+package generators
+
+import "fmt"
+
+// MFStandard определяет систему многочастотной сигнализации
+type MFStandard int
+
+const (
+	MFR1         MFStandard = iota // R1 (ITU-T Q.320, Bell MF): 700-1700 Гц
+	MFR2Forward                    // R2, прямое направление (ITU-T Q.441): 1380-1980 Гц
+	MFR2Backward                   // R2, обратное направление: 1140-540 Гц
+)
+
+// String возвращает строковое представление системы сигнализации
+func (s MFStandard) String() string {
+	switch s {
+	case MFR1:
+		return "MF R1"
+	case MFR2Forward:
+		return "MF R2 прямое"
+	case MFR2Backward:
+		return "MF R2 обратное"
+	default:
+		return "Неизвестная"
+	}
+}
+
+// MFR1Frequencies - частоты системы R1, Гц
+var MFR1Frequencies = [6]float64{700, 900, 1100, 1300, 1500, 1700}
+
+// MFR2ForwardFrequencies - частоты f0..f5 прямого направления R2, Гц
+var MFR2ForwardFrequencies = [6]float64{1380, 1500, 1620, 1740, 1860, 1980}
+
+// MFR2BackwardFrequencies - частоты f0..f5 обратного направления R2, Гц
+var MFR2BackwardFrequencies = [6]float64{1140, 1020, 900, 780, 660, 540}
+
+// MFR1Symbols - символы R1 и индексы пар частот в MFR1Frequencies
+// 'K' - KP, 'S' - ST, 'A' - STP (ST'), 'B' - ST2P, 'C' - ST3P
+var MFR1Symbols = map[rune][2]int{
+	'1': {0, 1}, '2': {0, 2}, '3': {1, 2}, '4': {0, 3}, '5': {1, 3},
+	'6': {2, 3}, '7': {0, 4}, '8': {1, 4}, '9': {2, 4}, '0': {3, 4},
+	'K': {2, 5}, 'S': {4, 5}, 'A': {1, 5}, 'B': {3, 5}, 'C': {0, 5},
+}
+
+// MFR2Symbols - сигналы R2 1..15 (обозначаются '1'-'9', 'A'-'F') и индексы пар частот f0..f5
+var MFR2Symbols = map[rune][2]int{
+	'1': {0, 1}, '2': {0, 2}, '3': {1, 2}, '4': {0, 3}, '5': {1, 3},
+	'6': {2, 3}, '7': {0, 4}, '8': {1, 4}, '9': {2, 4}, 'A': {3, 4},
+	'B': {0, 5}, 'C': {1, 5}, 'D': {2, 5}, 'E': {3, 5}, 'F': {4, 5},
+}
+
+// MFFrequencies возвращает пару частот символа в заданной системе сигнализации
+func MFFrequencies(standard MFStandard, symbol rune) (f1, f2 float64, err error) {
+	var freqs [6]float64
+	var symbols map[rune][2]int
+	switch standard {
+	case MFR1:
+		freqs, symbols = MFR1Frequencies, MFR1Symbols
+	case MFR2Forward:
+		freqs, symbols = MFR2ForwardFrequencies, MFR2Symbols
+	case MFR2Backward:
+		freqs, symbols = MFR2BackwardFrequencies, MFR2Symbols
+	default:
+		return 0, 0, fmt.Errorf("неизвестная система сигнализации: %d", standard)
+	}
+
+	pair, ok := symbols[symbol]
+	if !ok {
+		return 0, 0, fmt.Errorf("неизвестный символ %s: %q", standard, symbol)
+	}
+	return freqs[pair[0]], freqs[pair[1]], nil
+}
+
+// MFGenerator генерирует последовательности сигналов MF R1/R2 для проверки декодеров
+type MFGenerator struct {
+	Standard           MFStandard // Система сигнализации
+	SampleRate         float64    // Частота дискретизации в герцах
+	Amplitude          float64    // Амплитуда первого тона пары
+	ToneDuration       float64    // Длительность тона в секундах
+	KPDuration         float64    // Длительность сигнала KP в системе R1 (0 - как ToneDuration)
+	PauseDuration      float64    // Длительность паузы после тона в секундах
+	LeadingSilence     float64    // Тишина перед первым тоном в секундах
+	TwistDB            float64    // Уровень второго тона пары относительно первого, дБ
+	FrequencyDeviation float64    // Относительное отклонение частот (например, 0.015 = +1.5%)
+	NoiseAmplitude     float64    // СКО аддитивного гауссова шума
+	Seed               int64      // Начальное значение генератора шума
+}
+
+// NewMFGenerator создает генератор MF с типовыми временными параметрами
+// R1: тон 68 мс, KP 100 мс, пауза 68 мс; R2: тон 150 мс, пауза 100 мс
+func NewMFGenerator(standard MFStandard) *MFGenerator {
+	g := &MFGenerator{
+		Standard:      standard,
+		SampleRate:    8000.0,
+		Amplitude:     0.5,
+		ToneDuration:  0.068,
+		KPDuration:    0.100,
+		PauseDuration: 0.068,
+	}
+	if standard != MFR1 {
+		g.ToneDuration = 0.150
+		g.KPDuration = 0
+		g.PauseDuration = 0.100
+	}
+	return g
+}
+
+// Generate создает сигнал для последовательности символов
+func (g *MFGenerator) Generate(symbols string) ([]float64, error) {
+	pairs := make([][2]float64, 0, len(symbols))
+	durations := make([]float64, 0, len(symbols))
+	for _, s := range symbols {
+		f1, f2, err := MFFrequencies(g.Standard, s)
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, [2]float64{f1, f2})
+
+		duration := g.ToneDuration
+		if g.Standard == MFR1 && s == 'K' && g.KPDuration > 0 {
+			duration = g.KPDuration
+		}
+		durations = append(durations, duration)
+	}
+
+	return generateTonePairs(pairs, tonePairParams{
+		sampleRate:     g.SampleRate,
+		amplitude:      g.Amplitude,
+		toneDuration:   g.ToneDuration,
+		pauseDuration:  g.PauseDuration,
+		leadingSilence: g.LeadingSilence,
+		twistDB:        g.TwistDB,
+		deviation:      g.FrequencyDeviation,
+		noiseAmplitude: g.NoiseAmplitude,
+		seed:           g.Seed,
+		toneDurations:  durations,
+	})
+}
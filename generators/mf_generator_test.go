@@ -0,0 +1,64 @@
+package generators
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMFFrequencies(t *testing.T) {
+	tests := []struct {
+		standard MFStandard
+		symbol   rune
+		f1, f2   float64
+	}{
+		{MFR1, '1', 700, 900},
+		{MFR1, '0', 1300, 1500},
+		{MFR1, 'K', 1100, 1700},
+		{MFR1, 'S', 1500, 1700},
+		{MFR2Forward, '1', 1380, 1500},
+		{MFR2Forward, 'A', 1740, 1860},
+		{MFR2Forward, 'F', 1860, 1980},
+		{MFR2Backward, '1', 1140, 1020},
+		{MFR2Backward, 'F', 660, 540},
+	}
+
+	for _, tt := range tests {
+		f1, f2, err := MFFrequencies(tt.standard, tt.symbol)
+		if err != nil {
+			t.Fatalf("%v %q: unexpected error: %v", tt.standard, tt.symbol, err)
+		}
+		if f1 != tt.f1 || f2 != tt.f2 {
+			t.Errorf("%v %q: got (%v, %v), want (%v, %v)", tt.standard, tt.symbol, f1, f2, tt.f1, tt.f2)
+		}
+	}
+
+	if _, _, err := MFFrequencies(MFR1, 'F'); err == nil {
+		t.Error("expected error for symbol absent in R1")
+	}
+	if _, _, err := MFFrequencies(MFStandard(7), '1'); err == nil {
+		t.Error("expected error for unknown standard")
+	}
+}
+
+func TestMFGenerator(t *testing.T) {
+	gen := NewMFGenerator(MFR1)
+	signal, err := gen.Generate("K1")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	// KP 100 мс + пауза 68 мс + тон 68 мс + пауза 68 мс при 8 кГц
+	if want := 800 + 544*3; len(signal) != want {
+		t.Fatalf("expected %d samples, got %d", want, len(signal))
+	}
+	kp := signal[:800]
+	for _, f := range []float64{1100, 1700} {
+		if level := toneLevel(kp, f, 8000); math.Abs(level-0.5) > 0.02 {
+			t.Errorf("KP tone %.0f Hz level %f, want 0.5", f, level)
+		}
+	}
+
+	if _, err := NewMFGenerator(MFR2Forward).Generate("1G"); err == nil {
+		t.Error("expected error for invalid R2 symbol")
+	}
+}
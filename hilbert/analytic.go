@@ -0,0 +1,58 @@
+package hilbert
+
+import (
+	"fmt"
+
+	"github.com/Alexxtn105/dsp/fft"
+)
+
+// AnalyticSignal вычисляет аналитический сигнал блока через БПФ
+// Спектр блока умножается на h[k]: h[0] = 1, h[N/2] = 1 (для четного N),
+// h[k] = 2 для положительных частот и 0 для отрицательных.
+// Действительная часть результата совпадает с x, мнимая - преобразование Гильберта x.
+// Блок считается периодическим: для непериодических сигналов вблизи краев
+// возникает циклическое наложение (см. AnalyticSignalPadded).
+func AnalyticSignal(x []float64) []complex128 {
+	if len(x) == 0 {
+		return []complex128{}
+	}
+
+	spectrum := fft.FFTReal(x)
+	applyAnalyticMask(spectrum)
+	return fft.IFFT(spectrum)
+}
+
+// AnalyticSignalPadded вычисляет аналитический сигнал с дополнением нулями
+// Блок дополняется не менее чем padding нулями до длины, равной степени двойки,
+// что ослабляет циклическое наложение хвостов преобразования Гильберта
+// с противоположного края блока. Возвращается срез длины len(x).
+func AnalyticSignalPadded(x []float64, padding int) ([]complex128, error) {
+	if len(x) == 0 {
+		return nil, fmt.Errorf("input must not be empty")
+	}
+	if padding < 0 {
+		return nil, fmt.Errorf("padding must be non-negative, got %d", padding)
+	}
+
+	n := fft.NextPowerOfTwo(len(x) + padding)
+	padded := make([]float64, n)
+	copy(padded, x)
+
+	return AnalyticSignal(padded)[:len(x)], nil
+}
+
+// applyAnalyticMask обнуляет отрицательные частоты и удваивает положительные
+func applyAnalyticMask(spectrum []complex128) {
+	n := len(spectrum)
+	half := (n + 1) / 2 // Первый индекс после положительных частот (для нечетного N)
+	for k := 1; k < half; k++ {
+		spectrum[k] *= 2
+	}
+	start := half
+	if n%2 == 0 {
+		start = n/2 + 1 // Бин Найквиста сохраняется с весом 1
+	}
+	for k := start; k < n; k++ {
+		spectrum[k] = 0
+	}
+}
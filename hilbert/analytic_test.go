@@ -0,0 +1,98 @@
+package hilbert
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// TestAnalyticSignalPeriodic проверяет точность для сигнала с целым числом периодов
+func TestAnalyticSignalPeriodic(t *testing.T) {
+	for _, n := range []int{256, 250, 243} {
+		x := make([]float64, n)
+		for i := range x {
+			phase := 2 * math.Pi * 12 * float64(i) / float64(n)
+			x[i] = (1 + 0.5*math.Cos(2*math.Pi*float64(i)/float64(n))) * math.Cos(phase)
+		}
+
+		z := AnalyticSignal(x)
+		if len(z) != n {
+			t.Fatalf("n=%d: expected %d samples, got %d", n, n, len(z))
+		}
+		for i := range z {
+			envelope := 1 + 0.5*math.Cos(2*math.Pi*float64(i)/float64(n))
+			if math.Abs(real(z[i])-x[i]) > 1e-9 {
+				t.Fatalf("n=%d: real part differs from input at %d", n, i)
+			}
+			if math.Abs(cmplx.Abs(z[i])-envelope) > 1e-9 {
+				t.Fatalf("n=%d: envelope %f at %d, want %f", n, cmplx.Abs(z[i]), i, envelope)
+			}
+		}
+	}
+}
+
+// TestAnalyticSignalQuadrature проверяет, что синус является преобразованием Гильберта косинуса
+func TestAnalyticSignalQuadrature(t *testing.T) {
+	n := 128
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = math.Cos(2*math.Pi*5*float64(i)/float64(n) + 0.3)
+	}
+
+	z := AnalyticSignal(x)
+	for i := range z {
+		want := math.Sin(2*math.Pi*5*float64(i)/float64(n) + 0.3)
+		if math.Abs(imag(z[i])-want) > 1e-9 {
+			t.Fatalf("imag part %f at %d, want %f", imag(z[i]), i, want)
+		}
+	}
+
+	if len(AnalyticSignal(nil)) != 0 {
+		t.Error("empty input should give empty output")
+	}
+}
+
+// TestAnalyticSignalPadded проверяет дополнение нулями для непериодического блока
+func TestAnalyticSignalPadded(t *testing.T) {
+	n := 1000
+	fs := 8000.0
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = math.Sin(2 * math.Pi * 1013.7 * float64(i) / fs)
+	}
+
+	z, err := AnalyticSignalPadded(x, n)
+	if err != nil {
+		t.Fatalf("AnalyticSignalPadded failed: %v", err)
+	}
+	if len(z) != n {
+		t.Fatalf("expected %d samples, got %d", n, len(z))
+	}
+
+	// Внутри блока огибающая близка к 1, действительная часть совпадает с входом
+	for i := 100; i < n-100; i++ {
+		if math.Abs(cmplx.Abs(z[i])-1) > 0.02 {
+			t.Fatalf("envelope %f at %d, want ~1", cmplx.Abs(z[i]), i)
+		}
+	}
+	for i := range z {
+		if math.Abs(real(z[i])-x[i]) > 1e-9 {
+			t.Fatalf("real part differs from input at %d", i)
+		}
+	}
+
+	// Мгновенная частота внутри блока
+	for i := 200; i < n-200; i++ {
+		dphi := cmplx.Phase(z[i] * cmplx.Conj(z[i-1]))
+		if f := dphi * fs / (2 * math.Pi); math.Abs(f-1013.7) > 5 {
+			t.Fatalf("instantaneous frequency %f at %d, want 1013.7", f, i)
+		}
+	}
+
+	if _, err := AnalyticSignalPadded(x, -1); err == nil {
+		t.Error("expected error for negative padding")
+	}
+	if _, err := AnalyticSignalPadded(nil, 0); err == nil {
+		t.Error("expected error for empty input")
+	}
+}
@@ -0,0 +1,479 @@
+package hilbert
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Alexxtn105/dsp/windows"
+)
+
+// HilbertDesignMethod определяет метод расчета КИХ-фильтра Гильберта
+type HilbertDesignMethod int
+
+const (
+	DesignHamming        HilbertDesignMethod = iota // Оконный метод, окно Хэмминга
+	DesignHann                                      // Оконный метод, окно Ханна
+	DesignBlackmanHarris                            // Оконный метод, окно Блэкмана-Харриса
+	DesignNuttall                                   // Оконный метод, окно Наттолла
+	DesignKaiser                                    // Оконный метод, окно Кайзера
+	DesignEquiripple                                // Равноволновой фильтр (алгоритм Паркса-Мак-Клеллана)
+)
+
+// String возвращает строковое представление метода
+func (m HilbertDesignMethod) String() string {
+	switch m {
+	case DesignHamming:
+		return "Hamming"
+	case DesignHann:
+		return "Hann"
+	case DesignBlackmanHarris:
+		return "Blackman-Harris"
+	case DesignNuttall:
+		return "Nuttall"
+	case DesignKaiser:
+		return "Kaiser"
+	case DesignEquiripple:
+		return "Equiripple"
+	default:
+		return "Unknown"
+	}
+}
+
+// HilbertSpec описывает требования к преобразователю Гильберта
+// Оконные методы не зависят от границ полосы: они используются для выбора длины
+// и измерения неравномерности. Равноволновой расчет оптимизирует АЧХ на полосе,
+// симметричной относительно fs/4, с меньшей из двух переходных полос.
+type HilbertSpec struct {
+	SampleRate   float64             // Частота дискретизации (Гц)
+	PassbandLow  float64             // Нижняя граница рабочей полосы (Гц)
+	PassbandHigh float64             // Верхняя граница рабочей полосы (Гц)
+	MaxRipple    float64             // Допустимое отклонение АЧХ от 1 в рабочей полосе (0 - не задано)
+	Order        int                 // Длина фильтра (нечетная); 0 - минимальная, удовлетворяющая MaxRipple
+	MaxOrder     int                 // Верхняя граница длины при автоматическом выборе (0 - 4095)
+	Method       HilbertDesignMethod // Метод расчета
+	KaiserBeta   float64             // Параметр окна Кайзера (0 - по MaxRipple)
+}
+
+// HilbertDesignReport содержит характеристики рассчитанного фильтра
+type HilbertDesignReport struct {
+	Method           HilbertDesignMethod // Метод расчета
+	Order            int                 // Длина фильтра
+	GroupDelay       int                 // Групповая задержка (отсчеты)
+	Latency          float64             // Групповая задержка (секунды)
+	PassbandRipple   float64             // Максимальное отклонение АЧХ от 1 в рабочей полосе
+	PassbandRippleDB float64             // Неравномерность АЧХ в рабочей полосе (размах), дБ
+}
+
+// defaultMaxHilbertOrder - верхняя граница длины при автоматическом выборе
+const defaultMaxHilbertOrder = 4095
+
+// NewHilbertTransformFromSpec создает преобразователь Гильберта по требованиям к рабочей полосе
+// Возвращает также отчет с фактической неравномерностью АЧХ и задержкой.
+func NewHilbertTransformFromSpec(spec HilbertSpec) (*HilbertTransform, HilbertDesignReport, error) {
+	coeffs, report, err := DesignHilbert(spec)
+	if err != nil {
+		return nil, HilbertDesignReport{}, err
+	}
+	return newHilbertTransformFromCoefficients(coeffs), report, nil
+}
+
+// newHilbertTransformFromCoefficients создает преобразователь с заданными коэффициентами нечетной длины
+func newHilbertTransformFromCoefficients(coeffs []float64) *HilbertTransform {
+	order := len(coeffs)
+	return &HilbertTransform{
+		order:      order,
+		coeffs:     append([]float64{}, coeffs...),
+		delayLine:  make([]float64, order),
+		groupDelay: order / 2,
+	}
+}
+
+// DesignHilbert рассчитывает коэффициенты КИХ-фильтра Гильберта по требованиям
+func DesignHilbert(spec HilbertSpec) ([]float64, HilbertDesignReport, error) {
+	if err := spec.validate(); err != nil {
+		return nil, HilbertDesignReport{}, err
+	}
+
+	var coeffs []float64
+	var err error
+	if spec.Order > 0 {
+		order := spec.Order
+		if order%2 == 0 {
+			order++
+		}
+		coeffs, err = spec.design(order)
+	} else {
+		coeffs, err = spec.minimalDesign()
+	}
+	if err != nil {
+		return nil, HilbertDesignReport{}, err
+	}
+
+	ripple := spec.ripple(coeffs)
+	report := HilbertDesignReport{
+		Method:           spec.Method,
+		Order:            len(coeffs),
+		GroupDelay:       len(coeffs) / 2,
+		Latency:          float64(len(coeffs)/2) / spec.SampleRate,
+		PassbandRipple:   ripple,
+		PassbandRippleDB: math.Inf(1),
+	}
+	if ripple < 1 {
+		report.PassbandRippleDB = 20 * math.Log10((1+ripple)/(1-ripple))
+	}
+	return coeffs, report, nil
+}
+
+// validate проверяет корректность требований
+func (s HilbertSpec) validate() error {
+	if s.SampleRate <= 0 {
+		return fmt.Errorf("sample rate must be positive, got %f", s.SampleRate)
+	}
+	if s.PassbandLow <= 0 || s.PassbandHigh <= s.PassbandLow || s.PassbandHigh >= s.SampleRate/2 {
+		return fmt.Errorf("passband must satisfy 0 < low < high < %f, got [%f, %f]",
+			s.SampleRate/2, s.PassbandLow, s.PassbandHigh)
+	}
+	if s.MaxRipple < 0 || s.MaxRipple >= 1 || (s.MaxRipple > 0 && s.MaxRipple < 1e-9) {
+		return fmt.Errorf("max ripple must be 0 or in [1e-9, 1), got %g", s.MaxRipple)
+	}
+	if s.Order < 0 || (s.Order > 0 && s.Order < 3) {
+		return fmt.Errorf("order must be at least 3, got %d", s.Order)
+	}
+	if s.Order == 0 && s.MaxRipple == 0 {
+		return fmt.Errorf("either order or max ripple must be specified")
+	}
+	if s.Method < DesignHamming || s.Method > DesignEquiripple {
+		return fmt.Errorf("unknown design method: %d", s.Method)
+	}
+	if s.KaiserBeta < 0 {
+		return fmt.Errorf("kaiser beta must be non-negative, got %f", s.KaiserBeta)
+	}
+	return nil
+}
+
+// minimalDesign подбирает минимальную длину вида 4m+3, удовлетворяющую MaxRipple
+// Для длин 4m+1 крайние коэффициенты симметричного по полосе фильтра равны нулю,
+// поэтому перебираются только длины 4m+3. Неравномерность практически монотонно
+// убывает с длиной, что позволяет использовать экспоненциальный поиск и бисекцию.
+func (s HilbertSpec) minimalDesign() ([]float64, error) {
+	maxOrder := s.MaxOrder
+	if maxOrder == 0 {
+		maxOrder = defaultMaxHilbertOrder
+	}
+	maxM := (maxOrder - 3) / 4
+	if maxM < 0 {
+		return nil, fmt.Errorf("max order must be at least 3, got %d", maxOrder)
+	}
+
+	try := func(m int) ([]float64, bool) {
+		coeffs, err := s.design(4*m + 3)
+		if err != nil {
+			return nil, false
+		}
+		return coeffs, s.ripple(coeffs) <= s.MaxRipple
+	}
+
+	// Экспоненциальный поиск верхней границы
+	lo, hi := 0, 0
+	best, ok := try(hi)
+	for !ok {
+		if hi == maxM {
+			return nil, fmt.Errorf("ripple %g cannot be reached with order up to %d", s.MaxRipple, maxOrder)
+		}
+		lo, hi = hi+1, min(2*hi+1, maxM)
+		best, ok = try(hi)
+	}
+
+	// Бисекция между последней неудачной и удачной длиной
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if coeffs, ok := try(mid); ok {
+			best, hi = coeffs, mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return best, nil
+}
+
+// design рассчитывает фильтр заданной нечетной длины выбранным методом
+func (s HilbertSpec) design(order int) ([]float64, error) {
+	if s.Method == DesignEquiripple {
+		return designEquiripple(order, s.PassbandLow/s.SampleRate, s.PassbandHigh/s.SampleRate)
+	}
+
+	ideal := idealHilbert(order)
+	switch s.Method {
+	case DesignHamming:
+		return windows.ApplyHammingWindow(ideal), nil
+	case DesignHann:
+		return windows.ApplyHannWindow(ideal), nil
+	case DesignBlackmanHarris:
+		return windows.ApplyBlackmanHarrisWindow(ideal), nil
+	case DesignNuttall:
+		return windows.ApplyNuttallWindow(ideal), nil
+	default:
+		return windows.ApplyKaiserWindow(ideal, s.kaiserBeta()), nil
+	}
+}
+
+// kaiserBeta возвращает параметр окна Кайзера (по формуле Кайзера для заданного затухания)
+func (s HilbertSpec) kaiserBeta() float64 {
+	if s.KaiserBeta > 0 {
+		return s.KaiserBeta
+	}
+	if s.MaxRipple == 0 {
+		return 6
+	}
+	a := -20 * math.Log10(s.MaxRipple)
+	switch {
+	case a > 50:
+		return 0.1102 * (a - 8.7)
+	case a >= 21:
+		return 0.5842*math.Pow(a-21, 0.4) + 0.07886*(a-21)
+	default:
+		return 0
+	}
+}
+
+// ripple вычисляет максимальное отклонение АЧХ от 1 в рабочей полосе
+func (s HilbertSpec) ripple(coeffs []float64) float64 {
+	const points = 512
+	lowW := 2 * math.Pi * s.PassbandLow / s.SampleRate
+	highW := 2 * math.Pi * s.PassbandHigh / s.SampleRate
+
+	var worst float64
+	for i := 0; i <= points; i++ {
+		w := lowW + (highW-lowW)*float64(i)/points
+		worst = math.Max(worst, math.Abs(hilbertAmplitude(coeffs, w)-1))
+	}
+	return worst
+}
+
+// hilbertAmplitude вычисляет амплитудную характеристику антисимметричного фильтра
+// H(e^jw) = -j * A(w) * e^(-jwM), A(w) = 2 * sum h[M+k] * sin(kw)
+func hilbertAmplitude(coeffs []float64, w float64) float64 {
+	center := len(coeffs) / 2
+	var a float64
+	for k := 1; k <= center; k++ {
+		a += coeffs[center+k] * math.Sin(float64(k)*w)
+	}
+	return 2 * a
+}
+
+// idealHilbert возвращает усеченную импульсную характеристику идеального преобразователя
+// h[M+k] = 2/(πk) для нечетных k, 0 для четных
+func idealHilbert(order int) []float64 {
+	coeffs := make([]float64, order)
+	center := order / 2
+	for n := range coeffs {
+		k := n - center
+		if k%2 != 0 {
+			coeffs[n] = 2.0 / (math.Pi * float64(k))
+		}
+	}
+	return coeffs
+}
+
+// equirippleMinDelta - наименьшая неравномерность, достижимая в арифметике float64
+const equirippleMinDelta = 1e-12
+
+// designEquiripple рассчитывает равноволновой фильтр Гильберта нечетной длины
+// алгоритмом обмена Ремеза (low, high - границы полосы в долях частоты дискретизации).
+// Полоса расширяется до симметричной относительно fs/4 с меньшей из двух переходных
+// полос: иначе АЧХ вне полосы не контролируется и неограниченно растет. Для симметричной
+// полосы четные коэффициенты равны нулю, и A(w) = 2 * sum h[2j+1] * sin((2j+1)w) =
+// sin(w) * Q(cos 2w), где Q - полином степени J-1. Q аппроксимирует 1/sin(w) с весом
+// sin(w) на [w1, π/2], что хорошо обусловлено и при малой неравномерности.
+func designEquiripple(order int, low, high float64) ([]float64, error) {
+	m := order / 2
+	if m < 1 {
+		return nil, fmt.Errorf("order must be at least 3, got %d", order)
+	}
+	j := (m + 1) / 2 // Количество ненулевых (нечетных) коэффициентов h[M+k], k <= M
+	r := j + 1       // Количество точек альтернанса
+
+	// Сетка частот на [w1, π/2]
+	w1 := 2 * math.Pi * math.Min(low, 0.5-high)
+	gridSize := max(16*r, 64)
+	x := make([]float64, gridSize)
+	d := make([]float64, gridSize)  // Желаемая функция 1/sin(w)
+	wt := make([]float64, gridSize) // Вес sin(w)
+	for i := range x {
+		w := w1 + (math.Pi/2-w1)*float64(i)/float64(gridSize-1)
+		x[i] = math.Cos(2 * w)
+		wt[i] = math.Sin(w)
+		d[i] = 1 / wt[i]
+	}
+
+	// Начальные экстремальные частоты распределены равномерно
+	ext := make([]int, r)
+	for i := range ext {
+		ext[i] = i * (gridSize - 1) / (r - 1)
+	}
+
+	var poly *barycentric
+	for iter := 0; iter < 100; iter++ {
+		var delta float64
+		poly, delta = remezInterpolate(x, d, wt, ext)
+		if math.Abs(delta) < equirippleMinDelta {
+			return nil, fmt.Errorf("order %d is excessive for the passband: ripple is below numerical precision", order)
+		}
+
+		errs := make([]float64, gridSize)
+		maxErr := 0.0
+		for i := range x {
+			errs[i] = wt[i] * (d[i] - poly.eval(x[i]))
+			maxErr = math.Max(maxErr, math.Abs(errs[i]))
+		}
+
+		// Потеря альтернанса возникает, когда неравномерность приближается к точности
+		// вычислений; в этом случае используется последнее приближение
+		next := remezExtrema(errs, math.Abs(delta), r)
+		if len(next) < r {
+			if iter == 0 {
+				return nil, fmt.Errorf("equiripple design failed to converge for order %d", order)
+			}
+			break
+		}
+		ext = next
+		if maxErr-math.Abs(delta) <= 1e-7*math.Abs(delta) {
+			break
+		}
+	}
+
+	// Коэффициенты по отсчетам A(w) на равномерной сетке (дискретное синус-преобразование)
+	points := 2 * order
+	amplitude := make([]float64, points)
+	for n := 1; n < points; n++ {
+		w := math.Pi * float64(n) / float64(points)
+		amplitude[n] = math.Sin(w) * poly.eval(math.Cos(2*w))
+	}
+
+	coeffs := make([]float64, order)
+	for k := 1; k <= m; k += 2 {
+		var sum float64
+		for n := 1; n < points; n++ {
+			sum += amplitude[n] * math.Sin(float64(k)*math.Pi*float64(n)/float64(points))
+		}
+		coeffs[m+k] = sum / float64(points)
+		coeffs[m-k] = -coeffs[m+k]
+	}
+	return coeffs, nil
+}
+
+// barycentric - полином, заданный значениями в узлах (барицентрическая форма Лагранжа)
+type barycentric struct {
+	x, y, weights []float64
+}
+
+// newBarycentric вычисляет барицентрические веса для узлов x
+func newBarycentric(x, y []float64) *barycentric {
+	// Разности узлов масштабируются на 4/(длина интервала), чтобы произведения
+	// не выходили за пределы диапазона float64; общий множитель весов сокращается
+	lo, hi := x[0], x[0]
+	for _, v := range x {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	scale := 1.0
+	if hi > lo {
+		scale = 4 / (hi - lo)
+	}
+
+	weights := make([]float64, len(x))
+	for k := range x {
+		prod := 1.0
+		for j := range x {
+			if j != k {
+				prod *= scale * (x[k] - x[j])
+			}
+		}
+		weights[k] = 1 / prod
+	}
+	return &barycentric{x: x, y: y, weights: weights}
+}
+
+// eval вычисляет значение полинома в точке x
+func (b *barycentric) eval(x float64) float64 {
+	var num, den float64
+	for k, xk := range b.x {
+		d := x - xk
+		if math.Abs(d) < 1e-14 {
+			return b.y[k]
+		}
+		t := b.weights[k] / d
+		num += t * b.y[k]
+		den += t
+	}
+	return num / den
+}
+
+// remezInterpolate строит полином, проходящий через точки альтернанса с ошибкой ±delta
+// x, d, wt - узлы сетки, желаемая функция и вес; ext - индексы точек альтернанса
+func remezInterpolate(x, d, wt []float64, ext []int) (*barycentric, float64) {
+	r := len(ext)
+	xe := make([]float64, r)
+	for i, idx := range ext {
+		xe[i] = x[idx]
+	}
+
+	full := newBarycentric(xe, make([]float64, r))
+	var num, den float64
+	sign := 1.0
+	for k, idx := range ext {
+		num += full.weights[k] * d[idx]
+		den += sign * full.weights[k] / wt[idx]
+		sign = -sign
+	}
+	delta := num / den
+
+	// Полином степени r-2 через первые r-1 точек со значениями D - (-1)^k δ / W
+	y := make([]float64, r-1)
+	sign = 1.0
+	for k := range y {
+		y[k] = d[ext[k]] - sign*delta/wt[ext[k]]
+		sign = -sign
+	}
+	return newBarycentric(xe[:r-1], y), delta
+}
+
+// remezExtrema находит новые точки альтернанса: локальные экстремумы ошибки
+// с чередующимися знаками, не меньшие |delta| по модулю
+func remezExtrema(errs []float64, delta float64, r int) []int {
+	n := len(errs)
+	var candidates []int
+	for i := 0; i < n; i++ {
+		e := errs[i]
+		if math.Abs(e) < delta*(1-1e-3) {
+			continue
+		}
+		// Максимум для положительной ошибки, минимум для отрицательной
+		left := i == 0 || (e > 0 && e >= errs[i-1]) || (e < 0 && e <= errs[i-1])
+		right := i == n-1 || (e > 0 && e >= errs[i+1]) || (e < 0 && e <= errs[i+1])
+		if left && right {
+			candidates = append(candidates, i)
+		}
+	}
+
+	// Из соседних экстремумов одного знака оставляется наибольший
+	var alt []int
+	for _, idx := range candidates {
+		if len(alt) > 0 && errs[alt[len(alt)-1]]*errs[idx] > 0 {
+			if math.Abs(errs[idx]) > math.Abs(errs[alt[len(alt)-1]]) {
+				alt[len(alt)-1] = idx
+			}
+			continue
+		}
+		alt = append(alt, idx)
+	}
+
+	// Лишние точки отбрасываются с того края, где ошибка меньше
+	for len(alt) > r {
+		if math.Abs(errs[alt[0]]) < math.Abs(errs[alt[len(alt)-1]]) {
+			alt = alt[1:]
+		} else {
+			alt = alt[:len(alt)-1]
+		}
+	}
+	return alt
+}
@@ -0,0 +1,142 @@
+package hilbert
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// TestDesignHilbertMatchesDefault проверяет совпадение с конструктором по умолчанию
+func TestDesignHilbertMatchesDefault(t *testing.T) {
+	spec := HilbertSpec{SampleRate: 48000, PassbandLow: 1000, PassbandHigh: 23000, Order: 63, Method: DesignHamming}
+	coeffs, report, err := DesignHilbert(spec)
+	if err != nil {
+		t.Fatalf("DesignHilbert failed: %v", err)
+	}
+	want := NewHilbertTransform(48000, 63).GetCoefficients()
+	for i := range want {
+		if math.Abs(coeffs[i]-want[i]) > 1e-15 {
+			t.Fatalf("coefficient %d: got %g, want %g", i, coeffs[i], want[i])
+		}
+	}
+	if report.Order != 63 || report.GroupDelay != 31 || math.Abs(report.Latency-31.0/48000) > 1e-15 {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
+
+// TestDesignHilbertEquiripple проверяет, что равноволновой фильтр лучше оконных той же длины
+func TestDesignHilbertEquiripple(t *testing.T) {
+	base := HilbertSpec{SampleRate: 8000, PassbandLow: 300, PassbandHigh: 3700, Order: 31}
+
+	eq := base
+	eq.Method = DesignEquiripple
+	coeffs, eqReport, err := DesignHilbert(eq)
+	if err != nil {
+		t.Fatalf("equiripple design failed: %v", err)
+	}
+
+	// Антисимметрия коэффициентов
+	for i := range coeffs {
+		if math.Abs(coeffs[i]+coeffs[len(coeffs)-1-i]) > 1e-12 {
+			t.Fatalf("coefficients are not antisymmetric at %d", i)
+		}
+	}
+
+	for _, m := range []HilbertDesignMethod{DesignHamming, DesignHann, DesignBlackmanHarris, DesignNuttall, DesignKaiser} {
+		spec := base
+		spec.Method = m
+		_, report, err := DesignHilbert(spec)
+		if err != nil {
+			t.Fatalf("%v design failed: %v", m, err)
+		}
+		if eqReport.PassbandRipple >= report.PassbandRipple {
+			t.Errorf("equiripple ripple %g should be below %v ripple %g", eqReport.PassbandRipple, m, report.PassbandRipple)
+		}
+	}
+
+	// Неравномерность оптимального фильтра достигается во многих точках полосы
+	w1, w2 := 2*math.Pi*300/8000, 2*math.Pi*3700/8000
+	peaks := 0
+	prev := 0.0
+	for i := 0; i <= 2000; i++ {
+		e := 1 - hilbertAmplitude(coeffs, w1+(w2-w1)*float64(i)/2000)
+		if math.Abs(e) > 0.98*eqReport.PassbandRipple && e*prev <= 0 {
+			peaks++
+			prev = e
+		}
+	}
+	if peaks < 8 {
+		t.Errorf("expected equiripple behaviour, found %d alternating peaks", peaks)
+	}
+}
+
+// TestDesignHilbertMinimalOrder проверяет автоматический выбор длины по допуску
+func TestDesignHilbertMinimalOrder(t *testing.T) {
+	for _, m := range []HilbertDesignMethod{DesignKaiser, DesignEquiripple, DesignBlackmanHarris} {
+		spec := HilbertSpec{SampleRate: 8000, PassbandLow: 200, PassbandHigh: 3800, MaxRipple: 0.01, Method: m}
+		coeffs, report, err := DesignHilbert(spec)
+		if err != nil {
+			t.Fatalf("%v: DesignHilbert failed: %v", m, err)
+		}
+		if report.PassbandRipple > 0.01 || len(coeffs) != report.Order {
+			t.Errorf("%v: report %+v does not meet ripple 0.01", m, report)
+		}
+
+		// Более короткий фильтр того же вида не удовлетворяет требованию
+		shorter := spec
+		shorter.Order = report.Order - 4
+		_, r, err := DesignHilbert(shorter)
+		if err == nil && r.PassbandRipple <= 0.01 {
+			t.Errorf("%v: order %d is not minimal, %d also meets the spec", m, report.Order, shorter.Order)
+		}
+	}
+
+	// Равноволновой фильтр требует меньшей задержки, чем оконный
+	eq, _, _ := DesignHilbert(HilbertSpec{SampleRate: 8000, PassbandLow: 200, PassbandHigh: 3800, MaxRipple: 0.001, Method: DesignEquiripple})
+	hm, _, _ := DesignHilbert(HilbertSpec{SampleRate: 8000, PassbandLow: 200, PassbandHigh: 3800, MaxRipple: 0.001, Method: DesignHamming})
+	if len(eq) >= len(hm) {
+		t.Errorf("equiripple order %d should be below Hamming order %d", len(eq), len(hm))
+	}
+}
+
+// TestNewHilbertTransformFromSpec проверяет огибающую тона в рабочей полосе
+func TestNewHilbertTransformFromSpec(t *testing.T) {
+	spec := HilbertSpec{SampleRate: 8000, PassbandLow: 300, PassbandHigh: 3400, MaxRipple: 0.005, Method: DesignEquiripple}
+	ht, report, err := NewHilbertTransformFromSpec(spec)
+	if err != nil {
+		t.Fatalf("NewHilbertTransformFromSpec failed: %v", err)
+	}
+	if ht.GetGroupDelay() != report.GroupDelay {
+		t.Errorf("group delay %d, report %d", ht.GetGroupDelay(), report.GroupDelay)
+	}
+
+	for _, f := range []float64{350, 1000, 3300} {
+		ht.Reset()
+		for n := 0; n < 2000; n++ {
+			y := ht.Tick(math.Cos(2 * math.Pi * f * float64(n) / 8000))
+			if n > 2*report.Order {
+				if e := math.Abs(cmplx.Abs(y) - 1); e > report.PassbandRipple+1e-9 {
+					t.Fatalf("%.0f Hz: envelope error %g exceeds ripple %g", f, e, report.PassbandRipple)
+				}
+			}
+		}
+	}
+}
+
+func TestDesignHilbertErrors(t *testing.T) {
+	bad := []HilbertSpec{
+		{SampleRate: 0, PassbandLow: 100, PassbandHigh: 200, Order: 31},
+		{SampleRate: 8000, PassbandLow: 0, PassbandHigh: 200, Order: 31},
+		{SampleRate: 8000, PassbandLow: 300, PassbandHigh: 200, Order: 31},
+		{SampleRate: 8000, PassbandLow: 300, PassbandHigh: 4000, Order: 31},
+		{SampleRate: 8000, PassbandLow: 300, PassbandHigh: 3000},
+		{SampleRate: 8000, PassbandLow: 300, PassbandHigh: 3000, MaxRipple: 1.5},
+		{SampleRate: 8000, PassbandLow: 300, PassbandHigh: 3000, Order: 31, Method: HilbertDesignMethod(42)},
+		{SampleRate: 8000, PassbandLow: 10, PassbandHigh: 3990, MaxRipple: 1e-6, MaxOrder: 63},
+	}
+	for i, spec := range bad {
+		if _, _, err := DesignHilbert(spec); err == nil {
+			t.Errorf("case %d: expected error for %+v", i, spec)
+		}
+	}
+}
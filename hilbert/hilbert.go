@@ -1,8 +1,6 @@
 package hilbert
 
-import (
-	"math"
-)
+import "github.com/Alexxtn105/dsp/windows"
 
 // HilbertTransform реализует преобразование Гильберта на основе КИХ-фильтра
 type HilbertTransform struct {
@@ -47,33 +45,17 @@ func NewHilbertTransform(sampleRate float64, order int) *HilbertTransform {
 
 // calculateCoefficients вычисляет коэффициенты КИХ-фильтра Гильберта
 // Используется метод на основе импульсной характеристики идеального преобразователя
-// с применением окна Хэмминга для снижения эффекта Гиббса
+// с применением окна Хэмминга для снижения эффекта Гиббса.
+// Для выбора окна, равноволнового расчета и контроля неравномерности АЧХ
+// используйте NewHilbertTransformFromSpec.
 func (ht *HilbertTransform) calculateCoefficients() {
-	center := ht.order / 2
-
-	for n := 0; n < ht.order; n++ {
-		if n == center {
-			// В центре коэффициент равен нулю
-			ht.coeffs[n] = 0.0
-		} else {
-			// Импульсная характеристика идеального преобразователя Гильберта
-			k := n - center
-
-			// h[n] = (2/π) * (1 - cos(πn)) / n для нечетных n
-			// h[n] = 0 для четных n
-			if k%2 != 0 {
-				// Идеальный коэффициент
-				idealCoeff := 2.0 / (math.Pi * float64(k))
-
-				// Окно Хэмминга для сглаживания
-				window := 0.54 - 0.46*math.Cos(2.0*math.Pi*float64(n)/float64(ht.order-1))
-
-				ht.coeffs[n] = idealCoeff * window
-			} else {
-				ht.coeffs[n] = 0.0
-			}
-		}
+	ideal := idealHilbert(ht.order)
+	if ht.order == 1 {
+		// Единственный (центральный) коэффициент равен нулю; окно длины 1 не определено
+		ht.coeffs = ideal
+		return
 	}
+	ht.coeffs = windows.ApplyHammingWindow(ideal)
 }
 
 // Tick обрабатывает один входной отсчет и возвращает комплексный результат
@@ -104,3 +104,19 @@ func BenchmarkHilbertTransform(b *testing.B) {
 		ht.Tick(input)
 	}
 }
+
+// Тест вырожденных порядков 0 и 1: фильтр из одного нулевого коэффициента без NaN
+func TestHilbertDegenerateOrder(t *testing.T) {
+	for _, order := range []int{0, 1} {
+		ht := NewHilbertTransform(48000, order)
+		if len(ht.coeffs) != 1 || ht.coeffs[0] != 0 {
+			t.Errorf("order %d: coefficients = %v, want [0]", order, ht.coeffs)
+		}
+		for _, x := range []float64{1, -0.5, 0.25} {
+			y := ht.Tick(x)
+			if cmplx.IsNaN(y) || imag(y) != 0 || real(y) != x {
+				t.Errorf("order %d: Tick(%v) = %v, want (%v+0i)", order, x, y, x)
+			}
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package hilbert
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+// InstantaneousSample - результат анализа одного отсчета аналитического сигнала
+type InstantaneousSample struct {
+	Analytic  complex128 // Аналитический сигнал x + j·H{x}
+	Envelope  float64    // Огибающая |z|
+	Phase     float64    // Развернутая мгновенная фаза (радианы)
+	Frequency float64    // Мгновенная частота (Гц)
+}
+
+// InstantaneousAnalyzer вычисляет огибающую, развернутую мгновенную фазу и
+// мгновенную частоту на основе КИХ-преобразователя Гильберта
+// Мгновенная частота оценивается по приращению фазы между соседними отсчетами:
+// f[n] = arg(z[n]·conj(z[n-1]))·fs/(2π), что не требует явного развертывания фазы
+// и однозначно для |f| < fs/2.
+type InstantaneousAnalyzer struct {
+	ht         *HilbertTransform
+	sampleRate float64
+
+	prev    complex128 // Предыдущий аналитический отсчет
+	phase   float64    // Накопленная развернутая фаза
+	started bool       // Признак наличия предыдущего отсчета
+}
+
+// NewInstantaneousAnalyzer создает анализатор с преобразователем Гильберта порядка order
+func NewInstantaneousAnalyzer(sampleRate float64, order int) (*InstantaneousAnalyzer, error) {
+	if sampleRate <= 0 {
+		return nil, fmt.Errorf("sample rate must be positive, got %f", sampleRate)
+	}
+	if order < 3 {
+		return nil, fmt.Errorf("order must be at least 3, got %d", order)
+	}
+	return NewInstantaneousAnalyzerWithTransform(NewHilbertTransform(sampleRate, order), sampleRate)
+}
+
+// NewInstantaneousAnalyzerWithTransform создает анализатор на основе готового преобразователя
+// (например, рассчитанного NewHilbertTransformFromSpec)
+func NewInstantaneousAnalyzerWithTransform(ht *HilbertTransform, sampleRate float64) (*InstantaneousAnalyzer, error) {
+	if ht == nil {
+		return nil, fmt.Errorf("hilbert transform must not be nil")
+	}
+	if sampleRate <= 0 {
+		return nil, fmt.Errorf("sample rate must be positive, got %f", sampleRate)
+	}
+	return &InstantaneousAnalyzer{ht: ht, sampleRate: sampleRate}, nil
+}
+
+// Tick обрабатывает один входной отсчет в потоковом режиме
+// Результат относится к входному отсчету, поступившему GetDelay() отсчетов назад.
+func (ia *InstantaneousAnalyzer) Tick(x float64) InstantaneousSample {
+	z := ia.ht.Tick(x)
+
+	var dphi float64
+	if ia.started {
+		dphi = cmplx.Phase(z * cmplx.Conj(ia.prev))
+		ia.phase += dphi
+	} else {
+		ia.phase = cmplx.Phase(z)
+		ia.started = true
+	}
+	ia.prev = z
+
+	return InstantaneousSample{
+		Analytic:  z,
+		Envelope:  cmplx.Abs(z),
+		Phase:     ia.phase,
+		Frequency: dphi * ia.sampleRate / (2 * math.Pi),
+	}
+}
+
+// Process обрабатывает блок с компенсацией задержки
+// Блок дополняется GetDelay() нулями, первые GetDelay() выходных отсчетов отбрасываются,
+// так что i-й результат соответствует i-му входному отсчету. Состояние сбрасывается
+// до и после обработки. Вблизи краев блока (в пределах порядка фильтра) результаты
+// искажены переходным процессом.
+func (ia *InstantaneousAnalyzer) Process(input []float64) []InstantaneousSample {
+	ia.Reset()
+	delay := ia.GetDelay()
+	output := make([]InstantaneousSample, 0, len(input))
+	for n := 0; n < len(input)+delay; n++ {
+		var x float64
+		if n < len(input) {
+			x = input[n]
+		}
+		s := ia.Tick(x)
+		if n >= delay {
+			output = append(output, s)
+		}
+	}
+	ia.Reset()
+	return output
+}
+
+// Envelope возвращает огибающую блока с компенсацией задержки
+func (ia *InstantaneousAnalyzer) Envelope(input []float64) []float64 {
+	samples := ia.Process(input)
+	envelope := make([]float64, len(samples))
+	for i, s := range samples {
+		envelope[i] = s.Envelope
+	}
+	return envelope
+}
+
+// Phase возвращает развернутую мгновенную фазу блока с компенсацией задержки
+func (ia *InstantaneousAnalyzer) Phase(input []float64) []float64 {
+	samples := ia.Process(input)
+	phase := make([]float64, len(samples))
+	for i, s := range samples {
+		phase[i] = s.Phase
+	}
+	return phase
+}
+
+// Frequency возвращает мгновенную частоту блока (Гц) с компенсацией задержки
+func (ia *InstantaneousAnalyzer) Frequency(input []float64) []float64 {
+	samples := ia.Process(input)
+	frequency := make([]float64, len(samples))
+	for i, s := range samples {
+		frequency[i] = s.Frequency
+	}
+	return frequency
+}
+
+// Reset сбрасывает состояние анализатора и преобразователя
+func (ia *InstantaneousAnalyzer) Reset() {
+	ia.ht.Reset()
+	ia.prev = 0
+	ia.phase = 0
+	ia.started = false
+}
+
+// GetDelay возвращает задержку выхода относительно входа (отсчеты)
+func (ia *InstantaneousAnalyzer) GetDelay() int {
+	return ia.ht.GetGroupDelay()
+}
+
+// GetSampleRate возвращает частоту дискретизации
+func (ia *InstantaneousAnalyzer) GetSampleRate() float64 {
+	return ia.sampleRate
+}
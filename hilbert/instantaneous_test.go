@@ -0,0 +1,95 @@
+package hilbert
+
+import (
+	"math"
+	"testing"
+)
+
+// TestInstantaneousAnalyzerAM проверяет выделение огибающей АМ-сигнала
+func TestInstantaneousAnalyzerAM(t *testing.T) {
+	fs := 8000.0
+	ia, err := NewInstantaneousAnalyzer(fs, 101)
+	if err != nil {
+		t.Fatalf("NewInstantaneousAnalyzer failed: %v", err)
+	}
+
+	n := 4000
+	x := make([]float64, n)
+	envelope := make([]float64, n)
+	for i := range x {
+		ti := float64(i) / fs
+		envelope[i] = 1 + 0.5*math.Sin(2*math.Pi*20*ti)
+		x[i] = envelope[i] * math.Cos(2*math.Pi*1000*ti)
+	}
+
+	got := ia.Envelope(x)
+	if len(got) != n {
+		t.Fatalf("expected %d samples, got %d", n, len(got))
+	}
+	// Без компенсации задержки огибающая сдвинута на 50 отсчетов
+	for i := 200; i < n-200; i++ {
+		if math.Abs(got[i]-envelope[i]) > 0.02 {
+			t.Fatalf("envelope %f at %d, want %f", got[i], i, envelope[i])
+		}
+	}
+}
+
+// TestInstantaneousAnalyzerFM проверяет мгновенную частоту и фазу ЧМ-сигнала
+func TestInstantaneousAnalyzerFM(t *testing.T) {
+	fs := 8000.0
+	ia, err := NewInstantaneousAnalyzer(fs, 101)
+	if err != nil {
+		t.Fatalf("NewInstantaneousAnalyzer failed: %v", err)
+	}
+
+	n := 4000
+	fc, dev, fm := 1500.0, 300.0, 10.0
+	x := make([]float64, n)
+	for i := range x {
+		ti := float64(i) / fs
+		x[i] = math.Cos(2*math.Pi*fc*ti + dev/fm*math.Sin(2*math.Pi*fm*ti))
+	}
+
+	samples := ia.Process(x)
+	for i := 200; i < n-200; i++ {
+		want := fc + dev*math.Cos(2*math.Pi*fm*float64(i)/fs)
+		if math.Abs(samples[i].Frequency-want) > 10 {
+			t.Fatalf("frequency %f at %d, want %f", samples[i].Frequency, i, want)
+		}
+	}
+
+	// Развернутая фаза растет монотонно, средняя скорость соответствует несущей
+	for i := 1; i < n; i++ {
+		if samples[i].Phase <= samples[i-1].Phase {
+			t.Fatalf("phase is not increasing at %d", i)
+		}
+	}
+	rate := (samples[3400].Phase - samples[200].Phase) / (3200 / fs) / (2 * math.Pi) // Четыре периода модуляции
+	if math.Abs(rate-fc) > 5 {
+		t.Errorf("mean phase rate %f Hz, want %f", rate, fc)
+	}
+}
+
+// TestInstantaneousAnalyzerStreaming проверяет потоковый режим и задержку
+func TestInstantaneousAnalyzerStreaming(t *testing.T) {
+	fs := 8000.0
+	ia, _ := NewInstantaneousAnalyzer(fs, 64)
+	if ia.GetDelay() != 32 {
+		t.Errorf("delay %d, want 32", ia.GetDelay())
+	}
+
+	var last InstantaneousSample
+	for i := 0; i < 1000; i++ {
+		last = ia.Tick(math.Sin(2 * math.Pi * 440 * float64(i) / fs))
+	}
+	if math.Abs(last.Frequency-440) > 1 || math.Abs(last.Envelope-1) > 0.02 {
+		t.Errorf("streaming: frequency %f, envelope %f", last.Frequency, last.Envelope)
+	}
+
+	if _, err := NewInstantaneousAnalyzer(0, 31); err == nil {
+		t.Error("expected error for zero sample rate")
+	}
+	if _, err := NewInstantaneousAnalyzerWithTransform(nil, fs); err == nil {
+		t.Error("expected error for nil transform")
+	}
+}
@@ -0,0 +1,282 @@
+package hilbert
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+// niemitaloCoefficients - коэффициенты a классического фазорасщепителя Олли Нимитало
+// (секции y[n] = a²(x[n] + y[n-2]) - x[n-2]); разность фаз 90° с погрешностью
+// не более 0.71° в полосе от 0.0015·fs до 0.4985·fs
+// Первая строка - ветвь I, вторая - ветвь Q (с задержкой на один отсчет).
+var niemitaloCoefficients = [2][4]float64{
+	{0.4021921162426, 0.8561710882420, 0.9722909545651, 0.9952884791278},
+	{0.6923878, 0.9360654322959, 0.9882295226860, 0.9987488452737},
+}
+
+// allpassSection - всепропускающая секция второго порядка H(z) = (c - z^-2) / (1 - c z^-2)
+type allpassSection struct {
+	c      float64 // Коэффициент (a² в обозначениях Нимитало)
+	x1, x2 float64 // x[n-1], x[n-2]
+	y1, y2 float64 // y[n-1], y[n-2]
+}
+
+// tick обрабатывает один отсчет
+func (s *allpassSection) tick(x float64) float64 {
+	y := s.c*(x+s.y2) - s.x2
+	s.x2, s.x1 = s.x1, x
+	s.y2, s.y1 = s.y1, y
+	return y
+}
+
+// response вычисляет комплексную частотную характеристику секции
+func (s *allpassSection) response(w float64) complex128 {
+	z2 := cmplx.Exp(complex(0, -2*w))
+	c := complex(s.c, 0)
+	return (c - z2) / (1 - c*z2)
+}
+
+// PhaseSplitter реализует БИХ-фазорасщепитель на паре цепочек всепропускающих секций
+// Выходы I и Q имеют одинаковую (единичную) амплитуду на всех частотах, а разность фаз
+// близка к 90° в полосе [transition·fs, (0.5 - transition)·fs]. В отличие от КИХ-преобразователя,
+// задержка составляет лишь несколько отсчетов, но она частотно-зависима (фазовая
+// характеристика нелинейна), поэтому разделитель подходит для систем управления и
+// демодуляции, а не для точного восстановления формы сигнала.
+//
+// Коэффициенты рассчитываются как у полифазного полуполосного эллиптического фильтра
+// (Regalia, Mitra, Vaidyanathan) со сдвигом по частоте на fs/4: z^-2 -> -z^-2.
+// Четные коэффициенты образуют ветвь I, нечетные - ветвь Q с дополнительной задержкой
+// на один отсчет. Погрешность разности фаз ε связана с подавлением δ полуполосного
+// фильтра соотношением δ = sin(ε/2).
+type PhaseSplitter struct {
+	pathI      []allpassSection
+	pathQ      []allpassSection
+	qDelay     float64 // Задержка ветви Q на один отсчет
+	transition float64 // Относительная ширина переходной полосы
+	errorBound float64 // Максимальная погрешность разности фаз в рабочей полосе (градусы)
+}
+
+// NewPhaseSplitter создает фазорасщепитель с numCoefficients коэффициентами (секциями)
+// transition - относительная ширина переходной полосы (0 < transition < 0.25):
+// рабочая полоса [transition·fs, (0.5 - transition)·fs].
+// Точность растет с числом коэффициентов и шириной переходной полосы.
+func NewPhaseSplitter(numCoefficients int, transition float64) (*PhaseSplitter, error) {
+	if numCoefficients < 2 {
+		return nil, fmt.Errorf("number of coefficients must be at least 2, got %d", numCoefficients)
+	}
+	if transition <= 0 || transition >= 0.25 {
+		return nil, fmt.Errorf("transition must be in (0, 0.25), got %f", transition)
+	}
+
+	coeffs := designHalfBandAllpass(numCoefficients, 2*transition)
+	var pathI, pathQ []float64
+	for i, c := range coeffs {
+		if i%2 == 0 {
+			pathI = append(pathI, c)
+		} else {
+			pathQ = append(pathQ, c)
+		}
+	}
+	return newPhaseSplitter(pathI, pathQ, transition), nil
+}
+
+// NewPhaseSplitterForBand подбирает минимальное число коэффициентов, при котором
+// погрешность разности фаз в полосе [lowFreq, sampleRate/2 - lowFreq] не превышает maxErrorDeg
+func NewPhaseSplitterForBand(sampleRate, lowFreq, maxErrorDeg float64) (*PhaseSplitter, error) {
+	if sampleRate <= 0 {
+		return nil, fmt.Errorf("sample rate must be positive, got %f", sampleRate)
+	}
+	if maxErrorDeg <= 0 {
+		return nil, fmt.Errorf("max phase error must be positive, got %f", maxErrorDeg)
+	}
+
+	const maxCoefficients = 32
+	transition := lowFreq / sampleRate
+	for n := 2; n <= maxCoefficients; n++ {
+		ps, err := NewPhaseSplitter(n, transition)
+		if err != nil {
+			return nil, err
+		}
+		if ps.errorBound <= maxErrorDeg {
+			return ps, nil
+		}
+	}
+	return nil, fmt.Errorf("phase error %g° cannot be reached with %d coefficients", maxErrorDeg, maxCoefficients)
+}
+
+// NewNiemitaloPhaseSplitter создает фазорасщепитель с классическими коэффициентами Нимитало (4 + 4 секции)
+func NewNiemitaloPhaseSplitter() *PhaseSplitter {
+	var pathI, pathQ []float64
+	for i := 0; i < 4; i++ {
+		pathI = append(pathI, niemitaloCoefficients[0][i]*niemitaloCoefficients[0][i])
+		pathQ = append(pathQ, niemitaloCoefficients[1][i]*niemitaloCoefficients[1][i])
+	}
+	return newPhaseSplitter(pathI, pathQ, 0.0015)
+}
+
+// newPhaseSplitter создает фазорасщепитель с коэффициентами секций ветвей
+func newPhaseSplitter(pathI, pathQ []float64, transition float64) *PhaseSplitter {
+	ps := &PhaseSplitter{
+		pathI:      make([]allpassSection, len(pathI)),
+		pathQ:      make([]allpassSection, len(pathQ)),
+		transition: transition,
+	}
+	for i, c := range pathI {
+		ps.pathI[i].c = c
+	}
+	for i, c := range pathQ {
+		ps.pathQ[i].c = c
+	}
+	ps.errorBound = ps.PhaseError(transition, 0.5-transition)
+	return ps
+}
+
+// designHalfBandAllpass рассчитывает коэффициенты полифазного полуполосного
+// эллиптического фильтра (метод из библиотеки HIIR Лорана де Сораса)
+// transition - ширина переходной полосы полуполосного фильтра в долях fs/2 (0 < transition < 0.5)
+func designHalfBandAllpass(numCoefficients int, transition float64) []float64 {
+	k := math.Tan((1 - transition*2) * math.Pi / 4)
+	k *= k
+	kk := math.Pow(1-k*k, 0.25)
+	e := 0.5 * (1 - kk) / (1 + kk)
+	e2 := e * e
+	e4 := e2 * e2
+	q := e * (1 + e4*(2+e4*(15+150*e4))) // Номинальный параметр эллиптической функции
+
+	order := 2*numCoefficients + 1
+	coeffs := make([]float64, numCoefficients)
+	for i := range coeffs {
+		c := float64(i + 1)
+
+		// Числитель и знаменатель через тета-ряды
+		var num float64
+		sign := 1.0
+		for j := 0.0; ; j++ {
+			term := sign * math.Pow(q, j*(j+1)) * math.Sin((2*j+1)*c*math.Pi/float64(order))
+			num += term
+			sign = -sign
+			if math.Abs(term) < 1e-100 || j > 100 {
+				break
+			}
+		}
+		num *= math.Pow(q, 0.25)
+
+		den := 0.5
+		sign = -1.0
+		for j := 1.0; ; j++ {
+			term := sign * math.Pow(q, j*j) * math.Cos(2*j*c*math.Pi/float64(order))
+			den += term
+			sign = -sign
+			if math.Abs(term) < 1e-100 || j > 100 {
+				break
+			}
+		}
+
+		ww := num / den
+		wwsq := ww * ww
+		x := math.Sqrt((1-wwsq*k)*(1-wwsq/k)) / (1 + wwsq)
+		coeffs[i] = (1 - x) / (1 + x)
+	}
+	return coeffs
+}
+
+// Tick обрабатывает один отсчет и возвращает I + jQ
+// Для положительных частот Q отстает от I на 90°, т.е. выход аппроксимирует аналитический сигнал.
+func (ps *PhaseSplitter) Tick(x float64) complex128 {
+	i := x
+	for s := range ps.pathI {
+		i = ps.pathI[s].tick(i)
+	}
+
+	q := x
+	for s := range ps.pathQ {
+		q = ps.pathQ[s].tick(q)
+	}
+	q, ps.qDelay = ps.qDelay, q
+
+	return complex(i, q)
+}
+
+// Process обрабатывает блок отсчетов
+func (ps *PhaseSplitter) Process(input []float64) []complex128 {
+	output := make([]complex128, len(input))
+	for n, x := range input {
+		output[n] = ps.Tick(x)
+	}
+	return output
+}
+
+// Reset сбрасывает состояние всех секций
+func (ps *PhaseSplitter) Reset() {
+	for s := range ps.pathI {
+		ps.pathI[s] = allpassSection{c: ps.pathI[s].c}
+	}
+	for s := range ps.pathQ {
+		ps.pathQ[s] = allpassSection{c: ps.pathQ[s].c}
+	}
+	ps.qDelay = 0
+}
+
+// Response возвращает комплексные частотные характеристики ветвей I и Q
+// на нормированной частоте f (в долях частоты дискретизации)
+func (ps *PhaseSplitter) Response(f float64) (complex128, complex128) {
+	w := 2 * math.Pi * f
+	hi, hq := complex(1, 0), cmplx.Exp(complex(0, -w))
+	for s := range ps.pathI {
+		hi *= ps.pathI[s].response(w)
+	}
+	for s := range ps.pathQ {
+		hq *= ps.pathQ[s].response(w)
+	}
+	return hi, hq
+}
+
+// PhaseDifference возвращает разность фаз I - Q на нормированной частоте f (градусы)
+func (ps *PhaseSplitter) PhaseDifference(f float64) float64 {
+	hi, hq := ps.Response(f)
+	return cmplx.Phase(hi/hq) * 180 / math.Pi
+}
+
+// PhaseError возвращает максимальное отклонение разности фаз от 90° (градусы)
+// в полосе нормированных частот [fLow, fHigh]
+func (ps *PhaseSplitter) PhaseError(fLow, fHigh float64) float64 {
+	const points = 4096
+	var worst float64
+	for n := 0; n <= points; n++ {
+		f := fLow + (fHigh-fLow)*float64(n)/points
+		worst = math.Max(worst, math.Abs(ps.PhaseDifference(f)-90))
+	}
+	return worst
+}
+
+// GroupDelay возвращает групповую задержку ветви I на нормированной частоте f (отсчеты)
+func (ps *PhaseSplitter) GroupDelay(f float64) float64 {
+	const df = 1e-6
+	a, _ := ps.Response(f - df)
+	b, _ := ps.Response(f + df)
+	return -cmplx.Phase(b/a) / (2 * math.Pi * 2 * df)
+}
+
+// GetPhaseErrorBound возвращает гарантированную погрешность разности фаз в рабочей полосе (градусы)
+func (ps *PhaseSplitter) GetPhaseErrorBound() float64 {
+	return ps.errorBound
+}
+
+// GetBand возвращает рабочую полосу в долях частоты дискретизации
+func (ps *PhaseSplitter) GetBand() (float64, float64) {
+	return ps.transition, 0.5 - ps.transition
+}
+
+// GetCoefficients возвращает коэффициенты c = a² секций ветвей I и Q
+func (ps *PhaseSplitter) GetCoefficients() ([]float64, []float64) {
+	ci := make([]float64, len(ps.pathI))
+	cq := make([]float64, len(ps.pathQ))
+	for s := range ps.pathI {
+		ci[s] = ps.pathI[s].c
+	}
+	for s := range ps.pathQ {
+		cq[s] = ps.pathQ[s].c
+	}
+	return ci, cq
+}
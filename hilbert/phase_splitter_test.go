@@ -0,0 +1,101 @@
+package hilbert
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// TestPhaseSplitterQuadrature проверяет разность фаз I/Q на синусоиде
+func TestPhaseSplitterQuadrature(t *testing.T) {
+	ps, err := NewPhaseSplitter(8, 0.02)
+	if err != nil {
+		t.Fatalf("NewPhaseSplitter failed: %v", err)
+	}
+
+	for _, f := range []float64{0.03, 0.1, 0.25, 0.37, 0.47} {
+		ps.Reset()
+		n := 4000
+		z := make([]complex128, n)
+		for i := range z {
+			z[i] = ps.Tick(math.Cos(2 * math.Pi * f * float64(i)))
+		}
+
+		// Амплитуды ветвей единичны, вектор I + jQ вращается в положительном направлении
+		for i := n / 2; i < n; i++ {
+			if math.Abs(cmplx.Abs(z[i])-1) > 1e-3 {
+				t.Fatalf("f=%.2f: envelope %f at %d, want 1", f, cmplx.Abs(z[i]), i)
+			}
+			dphi := cmplx.Phase(z[i] * cmplx.Conj(z[i-1]))
+			if math.Abs(dphi-2*math.Pi*f) > 1e-3 {
+				t.Fatalf("f=%.2f: phase step %f, want %f", f, dphi, 2*math.Pi*f)
+			}
+		}
+	}
+}
+
+// TestPhaseSplitterErrorBound проверяет документированную границу погрешности
+func TestPhaseSplitterErrorBound(t *testing.T) {
+	prev := math.Inf(1)
+	for _, n := range []int{2, 4, 6, 8, 12} {
+		ps, err := NewPhaseSplitter(n, 0.02)
+		if err != nil {
+			t.Fatalf("n=%d: %v", n, err)
+		}
+		bound := ps.GetPhaseErrorBound()
+		if bound >= prev {
+			t.Errorf("n=%d: bound %g does not decrease (previous %g)", n, bound, prev)
+		}
+		prev = bound
+
+		low, high := ps.GetBand()
+		for f := low; f <= high; f += 0.001 {
+			if e := math.Abs(ps.PhaseDifference(f) - 90); e > bound+1e-9 {
+				t.Fatalf("n=%d: phase error %g at f=%f exceeds bound %g", n, e, f, bound)
+			}
+		}
+	}
+
+	if prev > 1e-3 {
+		t.Errorf("12 coefficients: bound %g°, want < 0.001°", prev)
+	}
+}
+
+// TestNiemitaloPhaseSplitter проверяет классические коэффициенты
+func TestNiemitaloPhaseSplitter(t *testing.T) {
+	ps := NewNiemitaloPhaseSplitter()
+	if bound := ps.GetPhaseErrorBound(); bound > 0.71 {
+		t.Errorf("phase error bound %g°, want <= 0.71°", bound)
+	}
+	if d := ps.GroupDelay(0.25); d > 2 {
+		t.Errorf("group delay at fs/4 is %f samples, want low latency", d)
+	}
+}
+
+// TestPhaseSplitterForBand проверяет подбор числа коэффициентов
+func TestPhaseSplitterForBand(t *testing.T) {
+	fs := 8000.0
+	ps, err := NewPhaseSplitterForBand(fs, 300, 0.1)
+	if err != nil {
+		t.Fatalf("NewPhaseSplitterForBand failed: %v", err)
+	}
+	if e := ps.PhaseError(300/fs, 3700/fs); e > 0.1 {
+		t.Errorf("phase error %g° in 300..3700 Hz, want <= 0.1°", e)
+	}
+
+	ci, cq := ps.GetCoefficients()
+	smaller, _ := NewPhaseSplitter(len(ci)+len(cq)-1, 300/fs)
+	if smaller.GetPhaseErrorBound() <= 0.1 {
+		t.Error("selected number of coefficients is not minimal")
+	}
+
+	if _, err := NewPhaseSplitter(1, 0.1); err == nil {
+		t.Error("expected error for one coefficient")
+	}
+	if _, err := NewPhaseSplitter(4, 0.3); err == nil {
+		t.Error("expected error for transition >= 0.25")
+	}
+	if _, err := NewPhaseSplitterForBand(fs, 300, 0); err == nil {
+		t.Error("expected error for zero phase error")
+	}
+}
@@ -0,0 +1,307 @@
+package spectral
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+
+	"github.com/Alexxtn105/dsp/filters"
+)
+
+// ARMethod - метод оценивания параметров авторегрессионной модели
+type ARMethod int
+
+const (
+	YuleWalker         ARMethod = iota // Автокорреляционный метод (уравнения Юла-Уокера, рекурсия Левинсона-Дурбина)
+	Burg                               // Метод Берга: минимизация суммы ошибок прямого и обратного предсказания по решетке
+	Covariance                         // Ковариационный метод: МНК по ошибке прямого предсказания без дополнения нулями
+	ModifiedCovariance                 // Модифицированный ковариационный метод: МНК по ошибкам прямого и обратного предсказания
+)
+
+// String возвращает название метода
+func (m ARMethod) String() string {
+	switch m {
+	case YuleWalker:
+		return "Yule-Walker"
+	case Burg:
+		return "Burg"
+	case Covariance:
+		return "Covariance"
+	case ModifiedCovariance:
+		return "Modified covariance"
+	default:
+		return "Unknown"
+	}
+}
+
+// ARModel - авторегрессионная модель x[n] = -a1·x[n-1] - ... - ap·x[n-p] + e[n]
+type ARModel struct {
+	Method       ARMethod
+	Coefficients []float64 // Коэффициенты многочлена A(z): [1, a1, ..., ap]
+	Reflection   []float64 // Коэффициенты отражения k1..kp
+	Variance     float64   // Дисперсия ошибки предсказания σ²
+}
+
+// EstimateAR оценивает авторегрессионную модель порядка order
+// Методы YuleWalker и Burg гарантируют устойчивость модели (|k| < 1);
+// для ковариационных методов коэффициенты отражения вычисляются обратной
+// рекурсией Левинсона и могут указывать на неустойчивость.
+func EstimateAR(x []float64, order int, method ARMethod) (*ARModel, error) {
+	if order < 1 {
+		return nil, fmt.Errorf("order must be positive, got %d", order)
+	}
+	if len(x) <= 2*order {
+		return nil, fmt.Errorf("signal must contain more than %d samples, got %d", 2*order, len(x))
+	}
+
+	var model *ARModel
+	var err error
+	switch method {
+	case YuleWalker:
+		model, err = yuleWalker(x, order)
+	case Burg:
+		model = burg(x, order)
+	case Covariance:
+		model, err = covarianceAR(x, order, false)
+	case ModifiedCovariance:
+		model, err = covarianceAR(x, order, true)
+	default:
+		return nil, fmt.Errorf("unknown AR method %d", method)
+	}
+	if err != nil {
+		return nil, err
+	}
+	model.Method = method
+	return model, nil
+}
+
+// yuleWalker решает уравнения Юла-Уокера по смещенной оценке автокорреляции
+func yuleWalker(x []float64, order int) (*ARModel, error) {
+	n := len(x)
+	r := make([]float64, order+1)
+	for lag := 0; lag <= order; lag++ {
+		for i := lag; i < n; i++ {
+			r[lag] += x[i] * x[i-lag]
+		}
+		r[lag] /= float64(n)
+	}
+	if r[0] == 0 {
+		return nil, fmt.Errorf("signal has zero energy")
+	}
+
+	a, k, e := levinsonDurbin(r, order)
+	return &ARModel{Coefficients: a, Reflection: k, Variance: e}, nil
+}
+
+// levinsonDurbin решает систему Юла-Уокера рекурсией Левинсона-Дурбина
+// Возвращает коэффициенты [1, a1..ap], коэффициенты отражения и ошибку предсказания.
+func levinsonDurbin(r []float64, order int) ([]float64, []float64, float64) {
+	a := make([]float64, order+1)
+	a[0] = 1
+	k := make([]float64, order)
+	e := r[0]
+
+	prev := make([]float64, order+1)
+	for m := 1; m <= order; m++ {
+		acc := r[m]
+		for i := 1; i < m; i++ {
+			acc += a[i] * r[m-i]
+		}
+		km := -acc / e
+		k[m-1] = km
+
+		copy(prev, a)
+		for i := 1; i < m; i++ {
+			a[i] = prev[i] + km*prev[m-i]
+		}
+		a[m] = km
+		e *= 1 - km*km
+	}
+	return a, k, e
+}
+
+// burg оценивает модель методом Берга
+func burg(x []float64, order int) *ARModel {
+	n := len(x)
+	f := append([]float64{}, x...) // Ошибки прямого предсказания
+	b := append([]float64{}, x...) // Ошибки обратного предсказания
+
+	a := make([]float64, order+1)
+	a[0] = 1
+	k := make([]float64, order)
+	prev := make([]float64, order+1)
+
+	var e float64
+	for _, v := range x {
+		e += v * v
+	}
+	e /= float64(n)
+
+	for m := 1; m <= order; m++ {
+		var num, den float64
+		for i := m; i < n; i++ {
+			num += f[i] * b[i-1]
+			den += f[i]*f[i] + b[i-1]*b[i-1]
+		}
+		var km float64
+		if den > 0 {
+			km = -2 * num / den
+		}
+		k[m-1] = km
+
+		copy(prev, a)
+		for i := 1; i < m; i++ {
+			a[i] = prev[i] + km*prev[m-i]
+		}
+		a[m] = km
+		e *= 1 - km*km
+
+		// Обновление ошибок в обратном порядке, чтобы не затереть b[i-1]
+		for i := n - 1; i >= m; i-- {
+			fi := f[i]
+			f[i] = fi + km*b[i-1]
+			b[i] = b[i-1] + km*fi
+		}
+	}
+	return &ARModel{Coefficients: a, Reflection: k, Variance: e}
+}
+
+// covarianceAR оценивает модель ковариационным (forwardBackward = false)
+// или модифицированным ковариационным методом
+func covarianceAR(x []float64, order int, forwardBackward bool) (*ARModel, error) {
+	n := len(x)
+	rows := n - order
+	if forwardBackward {
+		rows *= 2
+	}
+
+	// Строки системы: Σ a_k·x[i-k] = -x[i] (прямое) и Σ a_k·x[i-order+k] = -x[i-order] (обратное)
+	a := newComplexMatrix(rows, order)
+	rhs := newComplexMatrix(rows, 1)
+	row := 0
+	for i := order; i < n; i++ {
+		for kk := 1; kk <= order; kk++ {
+			a[row][kk-1] = complex(x[i-kk], 0)
+		}
+		rhs[row][0] = complex(-x[i], 0)
+		row++
+		if forwardBackward {
+			for kk := 1; kk <= order; kk++ {
+				a[row][kk-1] = complex(x[i-order+kk], 0)
+			}
+			rhs[row][0] = complex(-x[i-order], 0)
+			row++
+		}
+	}
+
+	solution, err := leastSquares(a, rhs)
+	if err != nil {
+		return nil, err
+	}
+	coeffs := make([]float64, order+1)
+	coeffs[0] = 1
+	for i := 1; i <= order; i++ {
+		coeffs[i] = real(solution[i-1][0])
+	}
+
+	// Дисперсия - средний квадрат невязки
+	var e float64
+	for r := 0; r < rows; r++ {
+		res := real(rhs[r][0])
+		for kk := 0; kk < order; kk++ {
+			res -= real(a[r][kk]) * coeffs[kk+1]
+		}
+		e += res * res
+	}
+	e /= float64(rows)
+
+	return &ARModel{Coefficients: coeffs, Reflection: stepDown(coeffs), Variance: e}, nil
+}
+
+// stepDown вычисляет коэффициенты отражения по коэффициентам модели (обратная рекурсия Левинсона)
+// Если на каком-либо шаге |k| = 1, оставшиеся коэффициенты не определены и равны ±1.
+func stepDown(coeffs []float64) []float64 {
+	p := len(coeffs) - 1
+	k := make([]float64, p)
+	a := append([]float64{}, coeffs...)
+	for m := p; m >= 1; m-- {
+		km := a[m]
+		k[m-1] = km
+		den := 1 - km*km
+		if den <= 0 {
+			for i := m - 2; i >= 0; i-- {
+				k[i] = math.Copysign(1, km)
+			}
+			break
+		}
+		prev := make([]float64, m)
+		prev[0] = 1
+		for i := 1; i < m; i++ {
+			prev[i] = (a[i] - km*a[m-i]) / den
+		}
+		a = prev
+	}
+	return k
+}
+
+// IsStable возвращает true, если все коэффициенты отражения по модулю меньше 1
+func (m *ARModel) IsStable() bool {
+	for _, k := range m.Reflection {
+		if math.Abs(k) >= 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// Order возвращает порядок модели
+func (m *ARModel) Order() int {
+	return len(m.Coefficients) - 1
+}
+
+// Density возвращает одностороннюю спектральную плотность мощности на частоте f (Гц)
+// P(f) = 2σ² / (fs·|A(e^{j2πf/fs})|²); на частотах 0 и fs/2 множитель 2 не применяется.
+func (m *ARModel) Density(f, sampleRate float64) float64 {
+	w := 2 * math.Pi * f / sampleRate
+	var a complex128
+	for k, c := range m.Coefficients {
+		a += complex(c, 0) * cmplx.Exp(complex(0, -w*float64(k)))
+	}
+	scale := 2.0
+	if f == 0 || f == sampleRate/2 {
+		scale = 1
+	}
+	return scale * m.Variance / (sampleRate * real(a*cmplx.Conj(a)))
+}
+
+// PowerSpectrum вычисляет спектр модели в points равноотстоящих точках на [0, fs/2]
+func (m *ARModel) PowerSpectrum(points int, sampleRate float64) (*PowerSpectrum, error) {
+	if points < 2 {
+		return nil, fmt.Errorf("number of points must be at least 2, got %d", points)
+	}
+	if sampleRate <= 0 {
+		return nil, fmt.Errorf("sample rate must be positive, got %f", sampleRate)
+	}
+
+	ps := &PowerSpectrum{
+		Frequencies: make([]float64, points),
+		Density:     make([]float64, points),
+	}
+	for i := range ps.Frequencies {
+		f := sampleRate / 2 * float64(i) / float64(points-1)
+		ps.Frequencies[i] = f
+		ps.Density[i] = m.Density(f, sampleRate)
+	}
+	return ps, nil
+}
+
+// SynthesisFilter создает всеполюсный формирующий фильтр √σ² / A(z)
+// При подаче белого шума единичной дисперсии на выходе получается процесс с оцененным спектром.
+func (m *ARModel) SynthesisFilter() *filters.IIRFilter {
+	return filters.NewIIRFilter([]float64{math.Sqrt(m.Variance)}, append([]float64{}, m.Coefficients...))
+}
+
+// WhiteningFilter создает обеляющий КИХ-фильтр A(z) (фильтр ошибки предсказания)
+func (m *ARModel) WhiteningFilter() *filters.IIRFilter {
+	return filters.NewIIRFilter(append([]float64{}, m.Coefficients...), []float64{1})
+}
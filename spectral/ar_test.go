@@ -0,0 +1,171 @@
+package spectral
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/Alexxtn105/dsp/filters"
+)
+
+// arProcess формирует реализацию AR-процесса, пропуская белый шум через всеполюсный фильтр
+func arProcess(a []float64, sigma float64, n int, seed int64) []float64 {
+	rng := rand.New(rand.NewSource(seed))
+	filter := filters.NewIIRFilter([]float64{sigma}, a)
+	// Отбрасываем переходный процесс фильтра
+	for i := 0; i < 1000; i++ {
+		filter.Tick(rng.NormFloat64())
+	}
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = filter.Tick(rng.NormFloat64())
+	}
+	return x
+}
+
+// polesToAR возвращает коэффициенты A(z) с парами комплексно-сопряженных полюсов
+// радиусов radii на нормированных частотах freqs
+func polesToAR(radii, freqs []float64) []float64 {
+	a := []float64{1}
+	for i, r := range radii {
+		w := 2 * math.Pi * freqs[i]
+		section := []float64{1, -2 * r * math.Cos(w), r * r}
+		next := make([]float64, len(a)+2)
+		for j, c := range a {
+			for k, d := range section {
+				next[j+k] += c * d
+			}
+		}
+		a = next
+	}
+	return a
+}
+
+// TestEstimateAR проверяет восстановление коэффициентов известного AR(4)-процесса всеми методами
+func TestEstimateAR(t *testing.T) {
+	// Две пары полюсов с радиусами 0.9 и 0.8: автокорреляционный метод смещен
+	// при полюсах вблизи единичной окружности, поэтому они взяты умеренными
+	want := polesToAR([]float64{0.9, 0.8}, []float64{0.1, 0.3})
+	x := arProcess(want, 1, 8192, 1)
+
+	for _, method := range []ARMethod{YuleWalker, Burg, Covariance, ModifiedCovariance} {
+		model, err := EstimateAR(x, 4, method)
+		if err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+		if model.Order() != 4 || model.Method != method {
+			t.Fatalf("%s: unexpected order %d or method %s", method, model.Order(), model.Method)
+		}
+		for i, c := range want {
+			if math.Abs(model.Coefficients[i]-c) > 0.05 {
+				t.Errorf("%s: a[%d] = %.4f, want %.4f", method, i, model.Coefficients[i], c)
+			}
+		}
+		if math.Abs(model.Variance-1) > 0.1 {
+			t.Errorf("%s: variance %.4f, want 1", method, model.Variance)
+		}
+		if !model.IsStable() {
+			t.Errorf("%s: model is unstable, reflection %v", method, model.Reflection)
+		}
+	}
+}
+
+// TestReflectionStepDown проверяет согласованность коэффициентов отражения с рекурсией Левинсона
+func TestReflectionStepDown(t *testing.T) {
+	x := arProcess([]float64{1, -1.5, 0.8}, 1, 2048, 2)
+	model, err := EstimateAR(x, 3, Burg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := stepDown(model.Coefficients)
+	for i := range k {
+		if math.Abs(k[i]-model.Reflection[i]) > 1e-9 {
+			t.Errorf("k[%d] = %g, want %g", i, k[i], model.Reflection[i])
+		}
+	}
+}
+
+// TestARFilters проверяет синтезирующий и обеляющий фильтры модели
+func TestARFilters(t *testing.T) {
+	a := []float64{1, -1.5, 0.8}
+	x := arProcess(a, 2, 8192, 3)
+	model, err := EstimateAR(x, 2, Burg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Ошибка предсказания должна быть практически некоррелированной
+	e := model.WhiteningFilter().Process(x)
+	var r0, r1 float64
+	for i := 1; i < len(e); i++ {
+		r0 += e[i] * e[i]
+		r1 += e[i] * e[i-1]
+	}
+	if rho := r1 / r0; math.Abs(rho) > 0.05 {
+		t.Errorf("whitened lag-1 correlation %.3f, want ~0", rho)
+	}
+	if v := r0 / float64(len(e)-1); math.Abs(v-4) > 0.3 {
+		t.Errorf("whitened variance %.3f, want 4", v)
+	}
+
+	synthesis := model.SynthesisFilter()
+	if !synthesis.IsStable() {
+		t.Error("synthesis filter is unstable")
+	}
+	// АЧХ синтезирующего фильтра согласована со спектральной плотностью модели
+	fs := 1000.0
+	for _, f := range []float64{50, 100, 300} {
+		h := synthesis.GetFrequencyResponse(f / fs)
+		want := 2 * real(h*complex(real(h), -imag(h))) / fs
+		if got := model.Density(f, fs); math.Abs(got-want) > 1e-9*want {
+			t.Errorf("density at %g Hz: %g, want %g", f, got, want)
+		}
+	}
+}
+
+// TestARPowerSpectrum проверяет положение пика и нормировку спектра
+func TestARPowerSpectrum(t *testing.T) {
+	fs := 1000.0
+	// Полюса 0.95·exp(±j2π·125/1000)
+	r, w := 0.95, 2*math.Pi*125/fs
+	a := []float64{1, -2 * r * math.Cos(w), r * r}
+	x := arProcess(a, 1, 16384, 4)
+
+	model, err := EstimateAR(x, 2, YuleWalker)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ps, err := model.PowerSpectrum(4097, fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f, _ := ps.Peak(); math.Abs(f-125) > 3 {
+		t.Errorf("peak at %.1f Hz, want 125", f)
+	}
+
+	var variance float64
+	for _, v := range x {
+		variance += v * v
+	}
+	variance /= float64(len(x))
+	if p := ps.Power(0, fs/2); math.Abs(p-variance)/variance > 0.05 {
+		t.Errorf("integrated power %.3f, signal variance %.3f", p, variance)
+	}
+}
+
+// TestEstimateARErrors проверяет проверку параметров
+func TestEstimateARErrors(t *testing.T) {
+	x := make([]float64, 8)
+	if _, err := EstimateAR(x, 0, Burg); err == nil {
+		t.Error("expected error for zero order")
+	}
+	if _, err := EstimateAR(x, 4, Burg); err == nil {
+		t.Error("expected error for short signal")
+	}
+	if _, err := EstimateAR(x, 2, YuleWalker); err == nil {
+		t.Error("expected error for zero signal")
+	}
+	if _, err := EstimateAR(append(x, 1), 2, ARMethod(9)); err == nil {
+		t.Error("expected error for unknown method")
+	}
+}
@@ -0,0 +1,88 @@
+package spectral
+
+import (
+	"math/cmplx"
+)
+
+// CrossSpectrum - односторонние авто- и взаимная спектральные плотности двух каналов
+// Взаимная плотность определена как Pxy = E[conj(X)·Y]: ее фаза равна разности фаз
+// канала y относительно x (для задержки y на D секунд фаза равна -2πfD).
+type CrossSpectrum struct {
+	Frequencies []float64    // Частоты (Гц)
+	Pxx         []float64    // Спектральная плотность канала x
+	Pyy         []float64    // Спектральная плотность канала y
+	Pxy         []complex128 // Взаимная спектральная плотность
+	Segments    int          // Число усредненных сегментов
+}
+
+// CrossSpectralDensity оценивает авто- и взаимные спектры сигналов x (вход) и y (выход) методом Уэлча
+func CrossSpectralDensity(x, y []float64, config WelchConfig) (*CrossSpectrum, error) {
+	est, err := welchAverage(x, y, config)
+	if err != nil {
+		return nil, err
+	}
+
+	bins := est.nfft/2 + 1
+	cs := &CrossSpectrum{
+		Frequencies: make([]float64, bins),
+		Pxx:         make([]float64, bins),
+		Pyy:         make([]float64, bins),
+		Pxy:         make([]complex128, bins),
+		Segments:    est.segments,
+	}
+	for k := 0; k < bins; k++ {
+		scale := oneSidedScale(k, est.nfft)
+		cs.Frequencies[k] = float64(k) * config.SampleRate / float64(est.nfft)
+		cs.Pxx[k] = scale * est.pxx[k]
+		cs.Pyy[k] = scale * est.pyy[k]
+		cs.Pxy[k] = complex(scale, 0) * est.pxy[k]
+	}
+	return cs, nil
+}
+
+// Coherence возвращает квадрат модуля функции когерентности |Pxy|² / (Pxx·Pyy) в [0, 1]
+// При усреднении одного сегмента когерентность тождественно равна 1.
+func (cs *CrossSpectrum) Coherence() []float64 {
+	result := make([]float64, len(cs.Pxy))
+	for k, pxy := range cs.Pxy {
+		den := cs.Pxx[k] * cs.Pyy[k]
+		if den == 0 {
+			continue
+		}
+		result[k] = min((real(pxy)*real(pxy)+imag(pxy)*imag(pxy))/den, 1)
+	}
+	return result
+}
+
+// H1 возвращает оценку передаточной функции H1 = Pxy / Pxx
+// Несмещена при шуме на выходе системы (канал y).
+func (cs *CrossSpectrum) H1() []complex128 {
+	result := make([]complex128, len(cs.Pxy))
+	for k, pxy := range cs.Pxy {
+		if cs.Pxx[k] != 0 {
+			result[k] = pxy / complex(cs.Pxx[k], 0)
+		}
+	}
+	return result
+}
+
+// H2 возвращает оценку передаточной функции H2 = Pyy / Pyx
+// Несмещена при шуме на входе системы (канал x).
+func (cs *CrossSpectrum) H2() []complex128 {
+	result := make([]complex128, len(cs.Pxy))
+	for k, pxy := range cs.Pxy {
+		if pxy != 0 {
+			result[k] = complex(cs.Pyy[k], 0) / cmplx.Conj(pxy)
+		}
+	}
+	return result
+}
+
+// Phase возвращает фазу взаимного спектра (рад) на каждой частоте
+func (cs *CrossSpectrum) Phase() []float64 {
+	result := make([]float64, len(cs.Pxy))
+	for k, pxy := range cs.Pxy {
+		result[k] = cmplx.Phase(pxy)
+	}
+	return result
+}
@@ -0,0 +1,158 @@
+package spectral
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/Alexxtn105/dsp/filters"
+	"github.com/Alexxtn105/dsp/windows"
+)
+
+// whiteNoise формирует белый гауссов шум
+func whiteNoise(n int, sigma float64, seed int64) []float64 {
+	rng := rand.New(rand.NewSource(seed))
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = sigma * rng.NormFloat64()
+	}
+	return x
+}
+
+// TestWelch проверяет уровень спектра белого шума и положение пика синусоиды
+func TestWelch(t *testing.T) {
+	fs := 1000.0
+	sigma := 0.5
+	x := whiteNoise(16384, sigma, 1)
+	for i := range x {
+		x[i] += math.Sin(2 * math.Pi * 125 * float64(i) / fs)
+	}
+
+	for _, window := range []func([]float64) []float64{nil, windows.ApplyHammingWindow, windows.ApplyBlackmanHarrisWindow} {
+		config := DefaultWelchConfig(fs, 256)
+		config.Window = window
+		ps, err := Welch(x, config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ps.Frequencies) != 129 {
+			t.Fatalf("got %d bins, want 129", len(ps.Frequencies))
+		}
+		if f, _ := ps.Peak(); f != 125 {
+			t.Errorf("peak at %f Hz, want 125", f)
+		}
+
+		// Фон вдали от синусоиды - 2σ²/fs
+		want := 2 * sigma * sigma / fs
+		var mean float64
+		count := 0
+		for i, f := range ps.Frequencies {
+			if f > 200 && f < 450 {
+				mean += ps.Density[i]
+				count++
+			}
+		}
+		mean /= float64(count)
+		if math.Abs(mean-want)/want > 0.05 {
+			t.Errorf("noise density %g, want %g", mean, want)
+		}
+
+		// Мощность синусоиды A²/2 = 0.5
+		if p := ps.Power(110, 140) - want*30; math.Abs(p-0.5) > 0.05 {
+			t.Errorf("sine power %f, want 0.5", p)
+		}
+	}
+}
+
+// firResponse вычисляет комплексную частотную характеристику КИХ-фильтра
+func firResponse(b []float64, f, fs float64) complex128 {
+	var h complex128
+	for k, c := range b {
+		h += complex(c, 0) * cmplx.Exp(complex(0, -2*math.Pi*f/fs*float64(k)))
+	}
+	return h
+}
+
+// TestTransferFunction проверяет оценки H1/H2 и когерентность для известной системы
+func TestTransferFunction(t *testing.T) {
+	fs := 1000.0
+	n := 65536
+	b := []float64{0.2, 0.5, 0.2, -0.1}
+	x := whiteNoise(n, 1, 2)
+	clean := filters.NewIIRFilter(b, []float64{1}).Process(x)
+
+	// Шум на выходе системы: H1 несмещена, H2 завышена
+	noise := whiteNoise(n, 0.2, 3)
+	y := make([]float64, n)
+	for i := range y {
+		y[i] = clean[i] + noise[i]
+	}
+
+	cs, err := CrossSpectralDensity(x, y, DefaultWelchConfig(fs, 256))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h1 := cs.H1()
+	h2 := cs.H2()
+	coherence := cs.Coherence()
+	for k, f := range cs.Frequencies {
+		if f < 20 || f > 300 {
+			continue
+		}
+		want := firResponse(b, f, fs)
+		if cmplx.Abs(h1[k]-want) > 0.05 {
+			t.Errorf("H1(%g Hz) = %v, want %v", f, h1[k], want)
+		}
+		// Теоретическая когерентность |H|²/(|H|² + σn²)
+		h2abs := real(want * cmplx.Conj(want))
+		wantCoh := h2abs / (h2abs + 0.04)
+		if math.Abs(coherence[k]-wantCoh) > 0.05 {
+			t.Errorf("coherence(%g Hz) = %f, want %f", f, coherence[k], wantCoh)
+		}
+		if cmplx.Abs(h2[k]) < cmplx.Abs(h1[k]) {
+			t.Errorf("|H2(%g Hz)| = %f is below |H1| = %f", f, cmplx.Abs(h2[k]), cmplx.Abs(h1[k]))
+		}
+	}
+
+	// Фаза взаимного спектра соответствует фазе системы
+	phase := cs.Phase()
+	if k := 25; math.Abs(phase[k]-cmplx.Phase(firResponse(b, cs.Frequencies[k], fs))) > 0.05 {
+		t.Errorf("cross-spectrum phase at %g Hz = %f", cs.Frequencies[k], phase[k])
+	}
+
+	// Независимые сигналы некогерентны
+	cs, _ = CrossSpectralDensity(x, noise, DefaultWelchConfig(fs, 256))
+	var mean float64
+	for _, c := range cs.Coherence() {
+		mean += c
+	}
+	mean /= float64(len(cs.Frequencies))
+	if mean > 0.01 {
+		t.Errorf("mean coherence of independent signals %f, want ~1/segments", mean)
+	}
+}
+
+// TestWelchErrors проверяет проверку параметров
+func TestWelchErrors(t *testing.T) {
+	x := make([]float64, 100)
+	if _, err := Welch(x, DefaultWelchConfig(0, 32)); err == nil {
+		t.Error("expected error for zero sample rate")
+	}
+	if _, err := Welch(x, DefaultWelchConfig(1000, 200)); err == nil {
+		t.Error("expected error for segment longer than signal")
+	}
+	config := DefaultWelchConfig(1000, 32)
+	config.Overlap = 32
+	if _, err := Welch(x, config); err == nil {
+		t.Error("expected error for full overlap")
+	}
+	config = DefaultWelchConfig(1000, 32)
+	config.NFFT = 16
+	if _, err := Welch(x, config); err == nil {
+		t.Error("expected error for short FFT")
+	}
+	if _, err := CrossSpectralDensity(x, x[:50], DefaultWelchConfig(1000, 32)); err == nil {
+		t.Error("expected error for length mismatch")
+	}
+}
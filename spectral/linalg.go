@@ -0,0 +1,374 @@
+package spectral
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"sort"
+)
+
+// Вспомогательные процедуры комплексной линейной алгебры для небольших плотных матриц
+
+// newComplexMatrix создает матрицу rows×cols
+func newComplexMatrix(rows, cols int) [][]complex128 {
+	m := make([][]complex128, rows)
+	for i := range m {
+		m[i] = make([]complex128, cols)
+	}
+	return m
+}
+
+// hermitianEigen вычисляет собственные значения и векторы эрмитовой матрицы
+// циклическим методом Якоби. Значения упорядочены по убыванию, векторы
+// возвращаются столбцами: vecs[i][k] - i-я компонента k-го вектора.
+// Исходная матрица не изменяется.
+func hermitianEigen(a [][]complex128) ([]float64, [][]complex128) {
+	n := len(a)
+	h := newComplexMatrix(n, n)
+	v := newComplexMatrix(n, n)
+	for i := range a {
+		copy(h[i], a[i])
+		v[i][i] = 1
+	}
+
+	const maxSweeps = 100
+	for sweep := 0; sweep < maxSweeps; sweep++ {
+		var off, diag float64
+		for i := 0; i < n; i++ {
+			diag += real(h[i][i]) * real(h[i][i])
+			for j := i + 1; j < n; j++ {
+				off += real(h[i][j])*real(h[i][j]) + imag(h[i][j])*imag(h[i][j])
+			}
+		}
+		if off <= 1e-30*diag || off == 0 {
+			break
+		}
+
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				apq := h[p][q]
+				mag := cmplx.Abs(apq)
+				if mag < 1e-300 {
+					continue
+				}
+
+				// Поворот J = D·R, где D = diag(1, e^{-jθ}) делает элемент (p,q) вещественным
+				phase := apq / complex(mag, 0)
+				tau := (real(h[q][q]) - real(h[p][p])) / (2 * mag)
+				t := 1 / (math.Abs(tau) + math.Sqrt(1+tau*tau))
+				if tau < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(1+t*t)
+				s := t * c
+
+				jpp := complex(c, 0)
+				jpq := complex(s, 0)
+				jqp := -complex(s, 0) * cmplx.Conj(phase)
+				jqq := complex(c, 0) * cmplx.Conj(phase)
+
+				// h ← h·J
+				for i := 0; i < n; i++ {
+					hp, hq := h[i][p], h[i][q]
+					h[i][p] = hp*jpp + hq*jqp
+					h[i][q] = hp*jpq + hq*jqq
+				}
+				// h ← J^H·h
+				for i := 0; i < n; i++ {
+					hp, hq := h[p][i], h[q][i]
+					h[p][i] = cmplx.Conj(jpp)*hp + cmplx.Conj(jqp)*hq
+					h[q][i] = cmplx.Conj(jpq)*hp + cmplx.Conj(jqq)*hq
+				}
+				h[p][q], h[q][p] = 0, 0
+				// v ← v·J
+				for i := 0; i < n; i++ {
+					vp, vq := v[i][p], v[i][q]
+					v[i][p] = vp*jpp + vq*jqp
+					v[i][q] = vp*jpq + vq*jqq
+				}
+			}
+		}
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool { return real(h[order[i]][order[i]]) > real(h[order[j]][order[j]]) })
+
+	values := make([]float64, n)
+	vectors := newComplexMatrix(n, n)
+	for k, idx := range order {
+		values[k] = real(h[idx][idx])
+		for i := 0; i < n; i++ {
+			vectors[i][k] = v[i][idx]
+		}
+	}
+	return values, vectors
+}
+
+// eigenvalues вычисляет собственные значения произвольной комплексной матрицы
+// (приведение к форме Хессенберга отражениями Хаусхолдера и QR-алгоритм со сдвигами Уилкинсона)
+func eigenvalues(a [][]complex128) ([]complex128, error) {
+	n := len(a)
+	h := newComplexMatrix(n, n)
+	for i := range a {
+		copy(h[i], a[i])
+	}
+	toHessenberg(h)
+
+	values := make([]complex128, 0, n)
+	hi := n - 1
+	iterations := 0
+	for hi >= 0 {
+		if hi == 0 {
+			values = append(values, h[0][0])
+			break
+		}
+
+		// Поиск малого поддиагонального элемента
+		lo := hi
+		for lo > 0 {
+			scale := cmplx.Abs(h[lo][lo]) + cmplx.Abs(h[lo-1][lo-1])
+			if scale == 0 {
+				scale = 1
+			}
+			if cmplx.Abs(h[lo][lo-1]) <= 1e-15*scale {
+				h[lo][lo-1] = 0
+				break
+			}
+			lo--
+		}
+		if lo == hi {
+			values = append(values, h[hi][hi])
+			hi--
+			iterations = 0
+			continue
+		}
+
+		iterations++
+		if iterations > 200 {
+			return nil, fmt.Errorf("QR algorithm did not converge")
+		}
+
+		// Сдвиг Уилкинсона по нижнему блоку 2×2 (исключительный сдвиг при застое)
+		var shift complex128
+		if iterations%20 == 0 {
+			shift = h[hi][hi] + complex(cmplx.Abs(h[hi][hi-1]), 0)
+		} else {
+			a11, a12, a21, a22 := h[hi-1][hi-1], h[hi-1][hi], h[hi][hi-1], h[hi][hi]
+			tr := (a11 + a22) / 2
+			det := cmplx.Sqrt((a11-a22)*(a11-a22)/4 + a12*a21)
+			s1, s2 := tr+det, tr-det
+			if cmplx.Abs(s1-a22) < cmplx.Abs(s2-a22) {
+				shift = s1
+			} else {
+				shift = s2
+			}
+		}
+
+		// QR-шаг на активном блоке [lo, hi] вращениями Гивенса
+		size := hi - lo + 1
+		cs := make([]float64, size-1)
+		sn := make([]complex128, size-1)
+		for i := lo; i <= hi; i++ {
+			h[i][i] -= shift
+		}
+		for k := lo; k < hi; k++ {
+			x, y := h[k][k], h[k+1][k]
+			r := math.Hypot(cmplx.Abs(x), cmplx.Abs(y))
+			var c float64
+			var s complex128
+			if r == 0 {
+				c, s = 1, 0
+			} else if x == 0 {
+				c, s = 0, cmplx.Conj(y)/complex(r, 0)
+			} else {
+				c = cmplx.Abs(x) / r
+				s = (x / complex(cmplx.Abs(x), 0)) * cmplx.Conj(y) / complex(r, 0)
+			}
+			cs[k-lo], sn[k-lo] = c, s
+			for j := k; j <= hi; j++ {
+				u, w := h[k][j], h[k+1][j]
+				h[k][j] = complex(c, 0)*u + s*w
+				h[k+1][j] = -cmplx.Conj(s)*u + complex(c, 0)*w
+			}
+		}
+		for k := lo; k < hi; k++ {
+			c, s := cs[k-lo], sn[k-lo]
+			for i := lo; i <= min(k+2, hi); i++ {
+				u, w := h[i][k], h[i][k+1]
+				h[i][k] = complex(c, 0)*u + cmplx.Conj(s)*w
+				h[i][k+1] = -s*u + complex(c, 0)*w
+			}
+		}
+		for i := lo; i <= hi; i++ {
+			h[i][i] += shift
+		}
+	}
+	return values, nil
+}
+
+// toHessenberg приводит матрицу к верхней форме Хессенберга (на месте)
+func toHessenberg(h [][]complex128) {
+	n := len(h)
+	for k := 0; k < n-2; k++ {
+		var norm float64
+		for i := k + 1; i < n; i++ {
+			norm += real(h[i][k])*real(h[i][k]) + imag(h[i][k])*imag(h[i][k])
+		}
+		norm = math.Sqrt(norm)
+		if norm == 0 {
+			continue
+		}
+
+		// Вектор отражения v = x + e^{j·arg(x0)}·||x||·e1
+		v := make([]complex128, n-k-1)
+		for i := range v {
+			v[i] = h[k+1+i][k]
+		}
+		alpha := complex(norm, 0)
+		if v[0] != 0 {
+			alpha *= v[0] / complex(cmplx.Abs(v[0]), 0)
+		}
+		v[0] += alpha
+		var vnorm float64
+		for _, x := range v {
+			vnorm += real(x)*real(x) + imag(x)*imag(x)
+		}
+		if vnorm == 0 {
+			continue
+		}
+
+		// H ← (I - 2vv^H/|v|²)·H·(I - 2vv^H/|v|²)
+		for j := 0; j < n; j++ {
+			var dot complex128
+			for i := range v {
+				dot += cmplx.Conj(v[i]) * h[k+1+i][j]
+			}
+			dot *= complex(2/vnorm, 0)
+			for i := range v {
+				h[k+1+i][j] -= v[i] * dot
+			}
+		}
+		for i := 0; i < n; i++ {
+			var dot complex128
+			for j := range v {
+				dot += h[i][k+1+j] * v[j]
+			}
+			dot *= complex(2/vnorm, 0)
+			for j := range v {
+				h[i][k+1+j] -= dot * cmplx.Conj(v[j])
+			}
+		}
+	}
+}
+
+// polynomialRoots вычисляет корни многочлена c[0]·z^n + c[1]·z^(n-1) + ... + c[n]
+// как собственные значения сопровождающей матрицы
+func polynomialRoots(c []complex128) ([]complex128, error) {
+	// Отбрасываем нулевые старшие коэффициенты
+	for len(c) > 0 && c[0] == 0 {
+		c = c[1:]
+	}
+	n := len(c) - 1
+	if n < 1 {
+		return nil, nil
+	}
+
+	companion := newComplexMatrix(n, n)
+	for j := 0; j < n; j++ {
+		companion[0][j] = -c[j+1] / c[0]
+	}
+	for i := 1; i < n; i++ {
+		companion[i][i-1] = 1
+	}
+	return eigenvalues(companion)
+}
+
+// solveComplex решает систему A·X = B методом Гаусса с выбором главного элемента
+// (A - n×n, B - n×k). Исходные матрицы не изменяются.
+func solveComplex(a, b [][]complex128) ([][]complex128, error) {
+	n := len(a)
+	k := len(b[0])
+	m := newComplexMatrix(n, n+k)
+	for i := 0; i < n; i++ {
+		copy(m[i], a[i])
+		copy(m[i][n:], b[i])
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for i := col + 1; i < n; i++ {
+			if cmplx.Abs(m[i][col]) > cmplx.Abs(m[pivot][col]) {
+				pivot = i
+			}
+		}
+		if cmplx.Abs(m[pivot][col]) < 1e-300 {
+			return nil, fmt.Errorf("matrix is singular")
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+
+		for i := col + 1; i < n; i++ {
+			f := m[i][col] / m[col][col]
+			for j := col; j < n+k; j++ {
+				m[i][j] -= f * m[col][j]
+			}
+		}
+	}
+
+	x := newComplexMatrix(n, k)
+	for c := 0; c < k; c++ {
+		for i := n - 1; i >= 0; i-- {
+			sum := m[i][n+c]
+			for j := i + 1; j < n; j++ {
+				sum -= m[i][j] * x[j][c]
+			}
+			x[i][c] = sum / m[i][i]
+		}
+	}
+	return x, nil
+}
+
+// leastSquares решает переопределенную систему A·X ≈ B методом наименьших квадратов
+// через нормальные уравнения A^H·A·X = A^H·B
+func leastSquares(a, b [][]complex128) ([][]complex128, error) {
+	rows, cols, k := len(a), len(a[0]), len(b[0])
+	ata := newComplexMatrix(cols, cols)
+	atb := newComplexMatrix(cols, k)
+	for r := 0; r < rows; r++ {
+		for i := 0; i < cols; i++ {
+			ci := cmplx.Conj(a[r][i])
+			for j := 0; j < cols; j++ {
+				ata[i][j] += ci * a[r][j]
+			}
+			for j := 0; j < k; j++ {
+				atb[i][j] += ci * b[r][j]
+			}
+		}
+	}
+	return solveComplex(ata, atb)
+}
+
+// hankelCovariance строит матрицу данных Ганкеля (m строк, N-m+1 столбцов)
+// и возвращает выборочную ковариационную матрицу R = X·X^H / (N-m+1)
+func hankelCovariance(x []complex128, m int) [][]complex128 {
+	snapshots := len(x) - m + 1
+	r := newComplexMatrix(m, m)
+	for s := 0; s < snapshots; s++ {
+		for i := 0; i < m; i++ {
+			xi := x[s+i]
+			for j := i; j < m; j++ {
+				r[i][j] += xi * cmplx.Conj(x[s+j])
+			}
+		}
+	}
+	scale := complex(1/float64(snapshots), 0)
+	for i := 0; i < m; i++ {
+		for j := i; j < m; j++ {
+			r[i][j] *= scale
+			r[j][i] = cmplx.Conj(r[i][j])
+		}
+	}
+	return r
+}
@@ -0,0 +1,75 @@
+package spectral
+
+import (
+	"math/cmplx"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// TestHermitianEigen проверяет разложение случайной эрмитовой матрицы
+func TestHermitianEigen(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	n := 7
+	a := newComplexMatrix(n, n)
+	for i := 0; i < n; i++ {
+		a[i][i] = complex(rng.NormFloat64(), 0)
+		for j := i + 1; j < n; j++ {
+			a[i][j] = complex(rng.NormFloat64(), rng.NormFloat64())
+			a[j][i] = cmplx.Conj(a[i][j])
+		}
+	}
+
+	values, vectors := hermitianEigen(a)
+	for k := 0; k < n; k++ {
+		if k > 0 && values[k] > values[k-1] {
+			t.Fatalf("eigenvalues are not sorted: %v", values)
+		}
+		for i := 0; i < n; i++ {
+			var av complex128
+			for j := 0; j < n; j++ {
+				av += a[i][j] * vectors[j][k]
+			}
+			if cmplx.Abs(av-complex(values[k], 0)*vectors[i][k]) > 1e-9 {
+				t.Fatalf("A·v != λ·v for eigenvalue %d", k)
+			}
+		}
+	}
+}
+
+// TestPolynomialRoots проверяет корни многочлена с известными корнями на единичной окружности
+func TestPolynomialRoots(t *testing.T) {
+	want := []complex128{
+		cmplx.Exp(complex(0, 0.3)), cmplx.Exp(complex(0, 0.35)),
+		0.9 * cmplx.Exp(complex(0, -2)), complex(-0.5, 0.1), complex(2, 0),
+	}
+
+	// Коэффициенты многочлена Π(z - r)
+	poly := []complex128{1}
+	for _, r := range want {
+		next := make([]complex128, len(poly)+1)
+		for i, c := range poly {
+			next[i] += c
+			next[i+1] -= c * r
+		}
+		poly = next
+	}
+
+	roots, err := polynomialRoots(poly)
+	if err != nil {
+		t.Fatalf("polynomialRoots failed: %v", err)
+	}
+	byPhase := func(s []complex128) {
+		sort.Slice(s, func(i, j int) bool { return cmplx.Phase(s[i]) < cmplx.Phase(s[j]) })
+	}
+	byPhase(want)
+	byPhase(roots)
+	if len(roots) != len(want) {
+		t.Fatalf("expected %d roots, got %d", len(want), len(roots))
+	}
+	for i := range want {
+		if cmplx.Abs(roots[i]-want[i]) > 1e-9 {
+			t.Errorf("root %d: %v, want %v", i, roots[i], want[i])
+		}
+	}
+}
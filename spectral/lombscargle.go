@@ -0,0 +1,106 @@
+package spectral
+
+import (
+	"fmt"
+	"math"
+)
+
+// LombScargle вычисляет периодограмму Ломба-Скаргла для неравномерно дискретизированного сигнала
+// t - моменты отсчетов (с), x - значения, freqs - частоты анализа (Гц).
+// Среднее значение сигнала вычитается. Результат пересчитан в одностороннюю спектральную
+// плотность мощности для средней частоты дискретизации, поэтому для равномерной сетки
+// он совпадает с классической периодограммой и сравним с другими оценками пакета.
+func LombScargle(t, x, freqs []float64) (*PowerSpectrum, error) {
+	n := len(x)
+	if len(t) != n {
+		return nil, fmt.Errorf("times and values must have equal length, got %d and %d", len(t), n)
+	}
+	if n < 3 {
+		return nil, fmt.Errorf("at least 3 samples are required, got %d", n)
+	}
+	for i := 1; i < n; i++ {
+		if t[i] <= t[i-1] {
+			return nil, fmt.Errorf("times must be strictly increasing at index %d", i)
+		}
+	}
+
+	var mean float64
+	for _, v := range x {
+		mean += v
+	}
+	mean /= float64(n)
+
+	// Средняя частота дискретизации: n отсчетов на интервале n·(t[n-1]-t[0])/(n-1)
+	duration := (t[n-1] - t[0]) * float64(n) / float64(n-1)
+
+	ps := &PowerSpectrum{
+		Frequencies: append([]float64{}, freqs...),
+		Density:     make([]float64, len(freqs)),
+	}
+	for i, f := range freqs {
+		if f < 0 {
+			return nil, fmt.Errorf("frequencies must be non-negative, got %f", f)
+		}
+		if f == 0 {
+			continue
+		}
+		w := 2 * math.Pi * f
+
+		// Сдвиг τ, делающий синусную и косинусную составляющие ортогональными
+		var s2, c2 float64
+		for _, ti := range t {
+			s2 += math.Sin(2 * w * ti)
+			c2 += math.Cos(2 * w * ti)
+		}
+		tau := math.Atan2(s2, c2) / (2 * w)
+
+		var xc, xs, cc, ss float64
+		for j, ti := range t {
+			c := math.Cos(w * (ti - tau))
+			s := math.Sin(w * (ti - tau))
+			v := x[j] - mean
+			xc += v * c
+			xs += v * s
+			cc += c * c
+			ss += s * s
+		}
+		var p float64
+		if cc > 0 {
+			p += xc * xc / cc
+		}
+		if ss > 0 {
+			p += xs * xs / ss
+		}
+		// Классическая нормировка P = ½(...); плотность 2P·T/N
+		ps.Density[i] = p * duration / float64(n)
+	}
+	return ps, nil
+}
+
+// LombScargleFrequencies формирует равномерную сетку частот для периодограммы Ломба-Скаргла
+// Шаг равен 1/(oversampling·T), где T - длительность записи; сетка заканчивается на maxFreq
+// (если maxFreq <= 0 - на половине средней частоты дискретизации).
+func LombScargleFrequencies(t []float64, oversampling, maxFreq float64) ([]float64, error) {
+	n := len(t)
+	if n < 2 {
+		return nil, fmt.Errorf("at least 2 samples are required, got %d", n)
+	}
+	if oversampling < 1 {
+		return nil, fmt.Errorf("oversampling must be at least 1, got %f", oversampling)
+	}
+	span := t[n-1] - t[0]
+	if span <= 0 {
+		return nil, fmt.Errorf("time span must be positive, got %f", span)
+	}
+	if maxFreq <= 0 {
+		maxFreq = float64(n-1) / span / 2
+	}
+
+	step := 1 / (oversampling * span)
+	count := int(maxFreq/step) + 1
+	freqs := make([]float64, count)
+	for i := range freqs {
+		freqs[i] = float64(i) * step
+	}
+	return freqs, nil
+}
@@ -0,0 +1,113 @@
+package spectral
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// TestLombScargleIrregular проверяет обнаружение синусоиды по неравномерным отсчетам
+func TestLombScargleIrregular(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	n := 400
+	// Случайные моменты отсчетов на интервале 10 с (средняя частота 40 Гц)
+	times := make([]float64, n)
+	for i := range times {
+		times[i] = 10 * rng.Float64()
+	}
+	sort.Float64s(times)
+
+	freq := 12.3
+	x := make([]float64, n)
+	for i, ti := range times {
+		x[i] = 5 + 1.5*math.Sin(2*math.Pi*freq*ti) + 0.3*rng.NormFloat64()
+	}
+
+	freqs, err := LombScargleFrequencies(times, 8, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last := freqs[len(freqs)-1]; last > 20 || last < 19.9 {
+		t.Errorf("grid ends at %f Hz, want about 20", last)
+	}
+
+	ps, err := LombScargle(times, x, freqs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, peak := ps.Peak()
+	if math.Abs(f-freq) > 0.02 {
+		t.Errorf("peak at %.3f Hz, want %.3f", f, freq)
+	}
+
+	// Мощность в окрестности пика близка к мощности синусоиды A²/2
+	if p := ps.Power(freq-0.3, freq+0.3); math.Abs(p-1.125)/1.125 > 0.15 {
+		t.Errorf("line power %.3f, want 1.125", p)
+	}
+
+	// Пик значительно превышает фон
+	var background float64
+	count := 0
+	for i, fi := range ps.Frequencies {
+		if math.Abs(fi-freq) > 1 {
+			background += ps.Density[i]
+			count++
+		}
+	}
+	if ratio := peak / (background / float64(count)); ratio < 100 {
+		t.Errorf("peak-to-background ratio %.1f, want > 100", ratio)
+	}
+}
+
+// TestLombScargleUniform проверяет совпадение с периодограммой при равномерной дискретизации
+func TestLombScargleUniform(t *testing.T) {
+	fs := 100.0
+	n := 200
+	rng := rand.New(rand.NewSource(2))
+	times := make([]float64, n)
+	x := make([]float64, n)
+	for i := range x {
+		times[i] = float64(i) / fs
+		x[i] = rng.NormFloat64()
+	}
+
+	// На частотах Фурье kfs/N периодограмма Ломба-Скаргла равна классической
+	var mean float64
+	for _, v := range x {
+		mean += v
+	}
+	mean /= float64(n)
+	freqs := []float64{5, 12.5, 31, 40.5}
+	ps, err := LombScargle(times, x, freqs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, f := range freqs {
+		var re, im float64
+		for j, v := range x {
+			re += (v - mean) * math.Cos(2*math.Pi*f*times[j])
+			im -= (v - mean) * math.Sin(2*math.Pi*f*times[j])
+		}
+		want := 2 * (re*re + im*im) / (float64(n) * fs)
+		if math.Abs(ps.Density[i]-want) > 1e-9*want {
+			t.Errorf("%g Hz: density %g, want %g", f, ps.Density[i], want)
+		}
+	}
+}
+
+// TestLombScargleErrors проверяет проверку параметров
+func TestLombScargleErrors(t *testing.T) {
+	if _, err := LombScargle([]float64{0, 1, 2}, []float64{1, 2}, []float64{1}); err == nil {
+		t.Error("expected error for length mismatch")
+	}
+	if _, err := LombScargle([]float64{0, 2, 1}, []float64{1, 2, 3}, []float64{1}); err == nil {
+		t.Error("expected error for unsorted times")
+	}
+	if _, err := LombScargle([]float64{0, 1, 2}, []float64{1, 2, 3}, []float64{-1}); err == nil {
+		t.Error("expected error for negative frequency")
+	}
+	if _, err := LombScargleFrequencies([]float64{0, 1}, 0.5, 0); err == nil {
+		t.Error("expected error for oversampling below 1")
+	}
+}
@@ -0,0 +1,423 @@
+package spectral
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Alexxtn105/dsp/fft"
+)
+
+// DPSS вычисляет дискретные вытянутые сфероидальные последовательности (окна Слепяна)
+// длины n с произведением времени на полосу nw. Возвращает count окон единичной энергии
+// и их коэффициенты концентрации энергии в полосе [-nw/n, nw/n].
+// Окна находятся как собственные векторы трехдиагональной матрицы, коммутирующей
+// с матрицей концентрации (бисекция по Штурму и обратные итерации).
+// Знаки выбраны по соглашению Персиваля-Уолдена: четные окна имеют положительную сумму,
+// нечетные начинаются с положительного лепестка.
+func DPSS(n int, nw float64, count int) ([][]float64, []float64, error) {
+	if n < 2 {
+		return nil, nil, fmt.Errorf("taper length must be at least 2, got %d", n)
+	}
+	if nw <= 0 || nw >= float64(n)/2 {
+		return nil, nil, fmt.Errorf("time-bandwidth product must be in (0, %d), got %f", n/2, nw)
+	}
+	if count < 1 || count > n {
+		return nil, nil, fmt.Errorf("number of tapers must be in [1, %d], got %d", n, count)
+	}
+
+	w := nw / float64(n)
+	diag := make([]float64, n)
+	off := make([]float64, n) // off[i] - элемент между строками i-1 и i
+	cos2w := math.Cos(2 * math.Pi * w)
+	for i := range diag {
+		c := (float64(n-1) - 2*float64(i)) / 2
+		diag[i] = c * c * cos2w
+		if i > 0 {
+			off[i] = float64(i) * float64(n-i) / 2
+		}
+	}
+
+	tapers := make([][]float64, count)
+	concentrations := make([]float64, count)
+	for k := 0; k < count; k++ {
+		// k-е по убыванию собственное значение - (n-k)-е по возрастанию
+		lambda := tridiagonalEigenvalue(diag, off, n-1-k)
+		v := tridiagonalEigenvector(diag, off, lambda)
+
+		var sign float64
+		if k%2 == 0 {
+			for _, x := range v {
+				sign += x
+			}
+		} else {
+			for i, x := range v {
+				sign += (float64(n-1) - 2*float64(i)) * x
+			}
+		}
+		if sign < 0 {
+			for i := range v {
+				v[i] = -v[i]
+			}
+		}
+
+		tapers[k] = v
+		concentrations[k] = taperConcentration(v, w)
+	}
+	return tapers, concentrations, nil
+}
+
+// sturmCount возвращает число собственных значений трехдиагональной матрицы, меньших x
+func sturmCount(diag, off []float64, x float64) int {
+	count := 0
+	q := 1.0
+	for i := range diag {
+		if i == 0 {
+			q = diag[0] - x
+		} else {
+			if q == 0 {
+				q = 1e-300
+			}
+			q = diag[i] - x - off[i]*off[i]/q
+		}
+		if q < 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// tridiagonalEigenvalue находит index-е (по возрастанию, с нуля) собственное значение бисекцией
+func tridiagonalEigenvalue(diag, off []float64, index int) float64 {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i, d := range diag {
+		r := math.Abs(off[i])
+		if i+1 < len(diag) {
+			r += math.Abs(off[i+1])
+		}
+		lo = math.Min(lo, d-r)
+		hi = math.Max(hi, d+r)
+	}
+	for iter := 0; iter < 200 && hi-lo > 1e-14*math.Max(1, math.Abs(lo)+math.Abs(hi)); iter++ {
+		mid := (lo + hi) / 2
+		if sturmCount(diag, off, mid) > index {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// tridiagonalEigenvector находит собственный вектор обратными итерациями
+// (трехдиагональная система решается методом Гаусса с выбором главного элемента)
+func tridiagonalEigenvector(diag, off []float64, lambda float64) []float64 {
+	n := len(diag)
+	// Небольшой сдвиг, чтобы матрица не была вырожденной
+	shift := lambda + 1e-10*math.Max(1, math.Abs(lambda))
+
+	v := make([]float64, n)
+	for i := range v {
+		v[i] = 1 / math.Sqrt(float64(n)) * (1 + 0.01*float64(i%7))
+	}
+	for iter := 0; iter < 3; iter++ {
+		v = solveTridiagonal(diag, off, shift, v)
+		var norm float64
+		for _, x := range v {
+			norm += x * x
+		}
+		norm = math.Sqrt(norm)
+		for i := range v {
+			v[i] /= norm
+		}
+	}
+	return v
+}
+
+// solveTridiagonal решает (T - shift·I)·y = b для симметричной трехдиагональной матрицы T
+func solveTridiagonal(diag, off []float64, shift float64, b []float64) []float64 {
+	n := len(diag)
+	// Строки хранятся тремя диагоналями: главная, первая и вторая верхние (заполнение при перестановках)
+	d := make([]float64, n)
+	u1 := make([]float64, n)
+	u2 := make([]float64, n)
+	l := make([]float64, n) // Поддиагональ
+	rhs := append([]float64{}, b...)
+	for i := 0; i < n; i++ {
+		d[i] = diag[i] - shift
+		if i+1 < n {
+			u1[i] = off[i+1]
+			l[i+1] = off[i+1]
+		}
+	}
+
+	for i := 0; i < n-1; i++ {
+		if math.Abs(l[i+1]) > math.Abs(d[i]) {
+			// Перестановка строк i и i+1
+			d[i], l[i+1] = l[i+1], d[i]
+			u1[i], d[i+1] = d[i+1], u1[i]
+			u2[i], u1[i+1] = u1[i+1], u2[i]
+			rhs[i], rhs[i+1] = rhs[i+1], rhs[i]
+		}
+		if d[i] == 0 {
+			d[i] = 1e-300
+		}
+		m := l[i+1] / d[i]
+		d[i+1] -= m * u1[i]
+		u1[i+1] -= m * u2[i]
+		rhs[i+1] -= m * rhs[i]
+	}
+	if d[n-1] == 0 {
+		d[n-1] = 1e-300
+	}
+
+	y := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		s := rhs[i]
+		if i+1 < n {
+			s -= u1[i] * y[i+1]
+		}
+		if i+2 < n {
+			s -= u2[i] * y[i+2]
+		}
+		y[i] = s / d[i]
+	}
+	return y
+}
+
+// taperConcentration вычисляет долю энергии окна в полосе [-w, w]
+// λ = Σ_m Σ_n v[m]·v[n]·sin(2πw(m-n))/(π(m-n))
+func taperConcentration(v []float64, w float64) float64 {
+	n := len(v)
+	lambda := 2 * w
+	var energy float64
+	for _, x := range v {
+		energy += x * x
+	}
+	lambda *= energy
+	for lag := 1; lag < n; lag++ {
+		var r float64
+		for i := lag; i < n; i++ {
+			r += v[i] * v[i-lag]
+		}
+		lambda += 2 * r * math.Sin(2*math.Pi*w*float64(lag)) / (math.Pi * float64(lag))
+	}
+	return lambda
+}
+
+// MultitaperConfig - параметры многооконной оценки Томсона
+type MultitaperConfig struct {
+	SampleRate float64 // Частота дискретизации (Гц)
+	NW         float64 // Произведение времени на полосу (полуширина полосы NW/N·fs)
+	Tapers     int     // Число окон (0 - 2·NW-1)
+	NFFT       int     // Размер БПФ (0 - ближайшая степень двойки не меньше длины сигнала)
+	Adaptive   bool    // Адаптивное взвешивание собственных спектров
+}
+
+// DefaultMultitaperConfig возвращает конфигурацию с NW = 4 и адаптивным взвешиванием
+func DefaultMultitaperConfig(sampleRate float64) MultitaperConfig {
+	return MultitaperConfig{
+		SampleRate: sampleRate,
+		NW:         4,
+		Adaptive:   true,
+	}
+}
+
+// MultitaperSpectrum - результат многооконной оценки
+type MultitaperSpectrum struct {
+	PowerSpectrum
+	DegreesOfFreedom []float64 // Эффективное число степеней свободы оценки на каждой частоте
+	FStatistic       []float64 // F-статистика Томсона для гармонической составляющей (2 и 2K-2 степеней свободы)
+	LineAmplitude    []float64 // Оценка амплитуды синусоиды на каждой частоте
+	Tapers           int       // Число использованных окон
+}
+
+// Multitaper вычисляет многооконную оценку спектральной плотности мощности Томсона
+// Собственные спектры вычисляются с окнами DPSS и усредняются с равными весами
+// либо адаптивными весами, подавляющими утечку из сильных участков спектра.
+// Дополнительно вычисляется F-тест на присутствие гармонических составляющих.
+func Multitaper(x []float64, config MultitaperConfig) (*MultitaperSpectrum, error) {
+	n := len(x)
+	if config.SampleRate <= 0 {
+		return nil, fmt.Errorf("sample rate must be positive, got %f", config.SampleRate)
+	}
+	k := config.Tapers
+	if k == 0 {
+		k = int(2*config.NW) - 1
+	}
+	if k < 2 {
+		return nil, fmt.Errorf("at least 2 tapers are required, got %d", k)
+	}
+	nfft := config.NFFT
+	if nfft == 0 {
+		nfft = fft.NextPowerOfTwo(n)
+	}
+	if nfft < n {
+		return nil, fmt.Errorf("FFT size %d is smaller than signal length %d", nfft, n)
+	}
+
+	tapers, lambdas, err := DPSS(n, config.NW, k)
+	if err != nil {
+		return nil, err
+	}
+
+	// Собственные коэффициенты J_k(f) и значения спектров окон на нулевой частоте U_k(0)
+	bins := nfft/2 + 1
+	coeffs := make([][]complex128, k)
+	u0 := make([]float64, k)
+	for t, taper := range tapers {
+		buf := make([]complex128, nfft)
+		for i, v := range x {
+			buf[i] = complex(v*taper[i], 0)
+			u0[t] += taper[i]
+		}
+		coeffs[t] = fft.FFT(buf)[:bins]
+	}
+
+	var variance, mean float64
+	for _, v := range x {
+		mean += v
+	}
+	mean /= float64(n)
+	for _, v := range x {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= float64(n)
+
+	fs := config.SampleRate
+	result := &MultitaperSpectrum{
+		PowerSpectrum: PowerSpectrum{
+			Frequencies: make([]float64, bins),
+			Density:     make([]float64, bins),
+		},
+		DegreesOfFreedom: make([]float64, bins),
+		FStatistic:       make([]float64, bins),
+		LineAmplitude:    make([]float64, bins),
+		Tapers:           k,
+	}
+
+	eigen := make([]float64, k)
+	for bin := 0; bin < bins; bin++ {
+		result.Frequencies[bin] = float64(bin) * fs / float64(nfft)
+		for t := range eigen {
+			c := coeffs[t][bin]
+			eigen[t] = real(c)*real(c) + imag(c)*imag(c)
+		}
+
+		var s, dof float64
+		if config.Adaptive {
+			s, dof = adaptiveWeighting(eigen, lambdas, variance)
+		} else {
+			for _, e := range eigen {
+				s += e
+			}
+			s /= float64(k)
+			dof = 2 * float64(k)
+		}
+
+		scale := 2.0
+		if bin == 0 || (nfft%2 == 0 && bin == bins-1) {
+			scale = 1
+		}
+		result.Density[bin] = scale * s / fs
+		result.DegreesOfFreedom[bin] = dof
+		result.FStatistic[bin], result.LineAmplitude[bin] = harmonicFTest(coeffs, u0, bin)
+	}
+	return result, nil
+}
+
+// adaptiveWeighting итеративно вычисляет адаптивные веса Томсона
+// d_k = √λ_k·S / (λ_k·S + (1-λ_k)·σ²), S = Σ d_k²·S_k / Σ d_k²
+// Возвращает оценку спектра и число степеней свободы 2(Σd_k²)² / Σd_k⁴.
+// При нулевой дисперсии сигнала утечки нет, и используются равные веса.
+func adaptiveWeighting(eigen, lambdas []float64, variance float64) (float64, float64) {
+	if variance == 0 {
+		var mean float64
+		for _, e := range eigen {
+			mean += e
+		}
+		return mean / float64(len(eigen)), 2 * float64(len(eigen))
+	}
+
+	s := (eigen[0] + eigen[1]) / 2
+	weights := make([]float64, len(eigen))
+	for iter := 0; iter < 100; iter++ {
+		var num, den float64
+		for t, e := range eigen {
+			d := math.Sqrt(lambdas[t]) * s / (lambdas[t]*s + (1-lambdas[t])*variance)
+			weights[t] = d * d
+			num += weights[t] * e
+			den += weights[t]
+		}
+		if den == 0 {
+			break
+		}
+		next := num / den
+		done := math.Abs(next-s) <= 1e-10*s
+		s = next
+		if done {
+			break
+		}
+	}
+
+	var sum, sumSq float64
+	for _, w := range weights {
+		sum += w
+		sumSq += w * w
+	}
+	if sumSq == 0 {
+		return s, 2 * float64(len(eigen))
+	}
+	return s, 2 * sum * sum / sumSq
+}
+
+// harmonicFTest вычисляет F-статистику Томсона и амплитуду синусоиды на частоте bin
+// μ = Σ U_k(0)·J_k / Σ U_k(0)², F = (K-1)·|μ|²·ΣU_k(0)² / Σ|J_k - μ·U_k(0)|²
+func harmonicFTest(coeffs [][]complex128, u0 []float64, bin int) (float64, float64) {
+	var sumU2 float64
+	var mu complex128
+	for t, u := range u0 {
+		sumU2 += u * u
+		mu += complex(u, 0) * coeffs[t][bin]
+	}
+	if sumU2 == 0 {
+		return 0, 0
+	}
+	mu /= complex(sumU2, 0)
+
+	var residual float64
+	for t, u := range u0 {
+		r := coeffs[t][bin] - mu*complex(u, 0)
+		residual += real(r)*real(r) + imag(r)*imag(r)
+	}
+	power := real(mu)*real(mu) + imag(mu)*imag(mu)
+	// Амплитуда действительной синусоиды вдвое больше модуля комплексной составляющей
+	amplitude := 2 * math.Sqrt(power)
+	if residual == 0 {
+		if power == 0 {
+			// Нулевой сигнал: гармонической составляющей нет
+			return 0, 0
+		}
+		return math.Inf(1), amplitude
+	}
+	return float64(len(u0)-1) * power * sumU2 / residual, amplitude
+}
+
+// FCritical возвращает порог F-статистики для уровня значимости alpha
+// F(2, 2K-2): P(F > f) = (1 + f/(K-1))^-(K-1)
+func (ms *MultitaperSpectrum) FCritical(alpha float64) float64 {
+	v := float64(ms.Tapers - 1)
+	return v * (math.Pow(alpha, -1/v) - 1)
+}
+
+// Lines возвращает частоты локальных максимумов F-статистики, превышающих порог для уровня значимости alpha
+func (ms *MultitaperSpectrum) Lines(alpha float64) []float64 {
+	threshold := ms.FCritical(alpha)
+	var lines []float64
+	for i := 1; i+1 < len(ms.FStatistic); i++ {
+		f := ms.FStatistic[i]
+		if f > threshold && f >= ms.FStatistic[i-1] && f > ms.FStatistic[i+1] {
+			lines = append(lines, ms.Frequencies[i])
+		}
+	}
+	return lines
+}
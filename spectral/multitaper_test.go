@@ -0,0 +1,199 @@
+package spectral
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// TestDPSS проверяет ортонормированность, четность и концентрацию окон Слепяна
+func TestDPSS(t *testing.T) {
+	n := 256
+	nw := 4.0
+	tapers, lambdas, err := DPSS(n, nw, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for a := range tapers {
+		for b := range tapers {
+			var dot float64
+			for i := range tapers[a] {
+				dot += tapers[a][i] * tapers[b][i]
+			}
+			want := 0.0
+			if a == b {
+				want = 1
+			}
+			if math.Abs(dot-want) > 1e-8 {
+				t.Errorf("<v%d, v%d> = %g, want %g", a, b, dot, want)
+			}
+		}
+
+		// Четные окна симметричны, нечетные - антисимметричны
+		parity := 1.0
+		if a%2 == 1 {
+			parity = -1
+		}
+		for i := 0; i < n/2; i++ {
+			if math.Abs(tapers[a][i]-parity*tapers[a][n-1-i]) > 1e-8 {
+				t.Fatalf("taper %d has wrong parity at %d", a, i)
+			}
+		}
+		var sum float64
+		for _, v := range tapers[a] {
+			sum += v
+		}
+		if a%2 == 0 && sum <= 0 {
+			t.Errorf("even taper %d should have positive sum", a)
+		}
+	}
+
+	// Коэффициенты концентрации убывают и близки к 1 для первых 2NW-1 окон
+	for i, l := range lambdas {
+		if l > 1 || l < 0.9 {
+			t.Errorf("lambda[%d] = %f, want close to 1", i, l)
+		}
+		if i > 0 && l >= lambdas[i-1] {
+			t.Errorf("concentrations are not decreasing: %v", lambdas)
+		}
+	}
+	if 1-lambdas[0] > 1e-9 {
+		t.Errorf("lambda[0] = %.12f, want 1 - O(1e-10)", lambdas[0])
+	}
+}
+
+// TestMultitaperWhiteNoise проверяет уровень спектра белого шума
+func TestMultitaperWhiteNoise(t *testing.T) {
+	fs := 1000.0
+	sigma := 2.0
+	rng := rand.New(rand.NewSource(1))
+	x := make([]float64, 1024)
+	for i := range x {
+		x[i] = sigma * rng.NormFloat64()
+	}
+
+	for _, adaptive := range []bool{false, true} {
+		config := DefaultMultitaperConfig(fs)
+		config.Adaptive = adaptive
+		ms, err := Multitaper(x, config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ms.Tapers != 7 {
+			t.Errorf("tapers = %d, want 7", ms.Tapers)
+		}
+
+		// Односторонняя плотность белого шума 2σ²/fs
+		want := 2 * sigma * sigma / fs
+		var mean float64
+		count := 0
+		for i, f := range ms.Frequencies {
+			if f > 10 && f < fs/2-10 {
+				mean += ms.Density[i]
+				count++
+			}
+		}
+		mean /= float64(count)
+		if math.Abs(mean-want)/want > 0.05 {
+			t.Errorf("adaptive=%v: mean density %g, want %g", adaptive, mean, want)
+		}
+		if p := ms.Power(0, fs/2); math.Abs(p-sigma*sigma)/(sigma*sigma) > 0.1 {
+			t.Errorf("adaptive=%v: total power %f, want %f", adaptive, p, sigma*sigma)
+		}
+		if dof := ms.DegreesOfFreedom[100]; dof < 10 || dof > 14 {
+			t.Errorf("adaptive=%v: degrees of freedom %f, want about 14", adaptive, dof)
+		}
+	}
+}
+
+// TestMultitaperFTest проверяет обнаружение гармоник F-тестом и оценку их амплитуды
+func TestMultitaperFTest(t *testing.T) {
+	fs := 1000.0
+	n := 512
+	nfft := 4096
+	rng := rand.New(rand.NewSource(2))
+	lines := []struct{ freq, amp float64 }{{62.5, 1.0}, {211.3, 0.5}}
+
+	x := make([]float64, n)
+	for i := range x {
+		ti := float64(i) / fs
+		x[i] = 0.3 * rng.NormFloat64()
+		for _, l := range lines {
+			x[i] += l.amp * math.Cos(2*math.Pi*l.freq*ti+0.7)
+		}
+	}
+
+	config := DefaultMultitaperConfig(fs)
+	config.NFFT = nfft
+	ms, err := Multitaper(x, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := ms.Lines(0.01 / float64(nfft/2))
+	if len(found) != len(lines) {
+		t.Fatalf("found lines %v, want %d lines", found, len(lines))
+	}
+	for i, l := range lines {
+		if math.Abs(found[i]-l.freq) > fs/float64(nfft) {
+			t.Errorf("line %d at %.2f Hz, want %.2f", i, found[i], l.freq)
+		}
+		bin := int(math.Round(found[i] * float64(nfft) / fs))
+		if math.Abs(ms.LineAmplitude[bin]-l.amp) > 0.15*l.amp {
+			t.Errorf("line %d amplitude %.3f, want %.3f", i, ms.LineAmplitude[bin], l.amp)
+		}
+	}
+
+	// Спектральный пик совпадает с более сильной гармоникой
+	if f, _ := ms.Peak(); math.Abs(f-62.5) > 2 {
+		t.Errorf("peak at %.2f Hz, want 62.5", f)
+	}
+}
+
+// TestMultitaperZeroSignal проверяет, что нулевой сигнал дает нулевые спектр и F-статистику
+func TestMultitaperZeroSignal(t *testing.T) {
+	x := make([]float64, 256)
+	for _, adaptive := range []bool{true, false} {
+		config := DefaultMultitaperConfig(1000)
+		config.Adaptive = adaptive
+		ms, err := Multitaper(x, config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range ms.Frequencies {
+			if ms.Density[i] != 0 || ms.FStatistic[i] != 0 || ms.LineAmplitude[i] != 0 {
+				t.Fatalf("adaptive=%v: bin %d density %g, F %g, amplitude %g, want 0",
+					adaptive, i, ms.Density[i], ms.FStatistic[i], ms.LineAmplitude[i])
+			}
+			if ms.DegreesOfFreedom[i] != 2*float64(ms.Tapers) {
+				t.Fatalf("adaptive=%v: bin %d has %g degrees of freedom, want %d",
+					adaptive, i, ms.DegreesOfFreedom[i], 2*ms.Tapers)
+			}
+		}
+		if lines := ms.Lines(0.01); len(lines) != 0 {
+			t.Errorf("adaptive=%v: found lines %v in zero signal", adaptive, lines)
+		}
+	}
+}
+
+// TestMultitaperErrors проверяет проверку параметров
+func TestMultitaperErrors(t *testing.T) {
+	x := make([]float64, 64)
+	if _, err := Multitaper(x, DefaultMultitaperConfig(0)); err == nil {
+		t.Error("expected error for zero sample rate")
+	}
+	config := DefaultMultitaperConfig(1000)
+	config.NFFT = 32
+	if _, err := Multitaper(x, config); err == nil {
+		t.Error("expected error for short FFT")
+	}
+	config = DefaultMultitaperConfig(1000)
+	config.NW = 40
+	if _, err := Multitaper(x, config); err == nil {
+		t.Error("expected error for too large NW")
+	}
+	if _, _, err := DPSS(64, 4, 0); err == nil {
+		t.Error("expected error for zero tapers")
+	}
+}
@@ -0,0 +1,42 @@
+package spectral
+
+// PowerSpectrum - односторонняя оценка спектральной плотности мощности действительного сигнала
+// Плотность нормирована так, что ее интеграл по [0, fs/2] равен мощности (дисперсии) сигнала.
+type PowerSpectrum struct {
+	Frequencies []float64 // Частоты (Гц)
+	Density     []float64 // Спектральная плотность мощности (ед.²/Гц)
+}
+
+// Power возвращает мощность в полосе [low, high] (Гц), интегрируя плотность методом трапеций
+// Интервалы между отсчетами, частично попадающие в полосу, обрезаются по ее границам;
+// плотность в точках среза интерполируется линейно.
+func (ps *PowerSpectrum) Power(low, high float64) float64 {
+	var power float64
+	for i := 1; i < len(ps.Frequencies); i++ {
+		f0, f1 := ps.Frequencies[i-1], ps.Frequencies[i]
+		a, b := max(f0, low), min(f1, high)
+		if b <= a {
+			continue
+		}
+		d0, d1 := ps.Density[i-1], ps.Density[i]
+		slope := (d1 - d0) / (f1 - f0)
+		da := d0 + slope*(a-f0)
+		db := d0 + slope*(b-f0)
+		power += (da + db) / 2 * (b - a)
+	}
+	return power
+}
+
+// Peak возвращает частоту и плотность максимума спектра
+func (ps *PowerSpectrum) Peak() (float64, float64) {
+	best := 0
+	for i, d := range ps.Density {
+		if d > ps.Density[best] {
+			best = i
+		}
+	}
+	if len(ps.Density) == 0 {
+		return 0, 0
+	}
+	return ps.Frequencies[best], ps.Density[best]
+}
@@ -0,0 +1,36 @@
+package spectral
+
+import (
+	"math"
+	"testing"
+)
+
+// TestPowerSpectrumPowerPartialBins проверяет интегрирование в полосе с границами между отсчетами
+func TestPowerSpectrumPowerPartialBins(t *testing.T) {
+	// Линейная плотность D(f) = f на сетке с шагом 10 Гц: трапеции точны
+	ps := &PowerSpectrum{}
+	for f := 0.0; f <= 100; f += 10 {
+		ps.Frequencies = append(ps.Frequencies, f)
+		ps.Density = append(ps.Density, f)
+	}
+
+	cases := []struct {
+		low, high, want float64
+	}{
+		{0, 100, 5000},
+		{20, 40, 600},       // Границы на отсчетах
+		{13, 37, 600},       // Границы между отсчетами
+		{12, 14, 26},        // Узкая полоса внутри одного интервала
+		{95, 200, 487.5},    // Полоса выходит за сетку
+		{-50, 5, 12.5},      // Полоса начинается ниже сетки
+		{40, 40, 0},         // Нулевая ширина
+		{60, 30, 0},         // Перевернутые границы
+		{150, 200, 0},       // Полоса вне сетки
+		{33.3, 33.4, 3.335}, // Очень узкая полоса
+	}
+	for _, c := range cases {
+		if p := ps.Power(c.low, c.high); math.Abs(p-c.want) > 1e-9 {
+			t.Errorf("Power(%g, %g) = %g, want %g", c.low, c.high, p, c.want)
+		}
+	}
+}
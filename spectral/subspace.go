@@ -0,0 +1,377 @@
+// Package spectral содержит методы спектрального оценивания: параметрические
+// (подпространственные, авторегрессионные), непараметрические оценки
+// спектральной плотности мощности и взаимный спектральный анализ двух каналов.
+package spectral
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"sort"
+)
+
+// SinusoidMethod - метод оценивания параметров суммы экспонент
+type SinusoidMethod int
+
+const (
+	RootMUSIC    SinusoidMethod = iota // Корневой MUSIC: корни многочлена шумового подпространства
+	ESPRIT                             // ESPRIT: инвариантность сигнального подпространства к сдвигу
+	MatrixPencil                       // Метод матричного пучка (Hua & Sarkar)
+	Prony                              // Метод Прони (линейное предсказание МНК), чувствителен к шуму
+	Pisarenko                          // Гармоническое разложение Писаренко: MUSIC с размерностью Order+1
+)
+
+// String возвращает название метода
+func (m SinusoidMethod) String() string {
+	switch m {
+	case RootMUSIC:
+		return "Root-MUSIC"
+	case ESPRIT:
+		return "ESPRIT"
+	case MatrixPencil:
+		return "Matrix pencil"
+	case Prony:
+		return "Prony"
+	case Pisarenko:
+		return "Pisarenko"
+	default:
+		return "Unknown"
+	}
+}
+
+// Sinusoid - параметры одной компоненты модели x[n] = Σ A·e^{jφ}·exp((-d + j2πf)·n/fs)
+type Sinusoid struct {
+	Frequency float64 // Частота (Гц), для комплексного сигнала может быть отрицательной
+	Amplitude float64 // Амплитуда A
+	Phase     float64 // Начальная фаза φ (радианы)
+	Damping   float64 // Коэффициент затухания d (1/с); 0 - незатухающая компонента
+}
+
+// SinusoidConfig - параметры оценивания
+type SinusoidConfig struct {
+	SampleRate float64        // Частота дискретизации (Гц)
+	Order      int            // Число комплексных экспонент (для EstimateRealSinusoids - число тонов)
+	Method     SinusoidMethod // Метод оценивания
+	Dimension  int            // Размер ковариационной матрицы / параметр пучка (0 - N/3; для Pisarenko не используется)
+}
+
+// validate проверяет параметры и возвращает размерность подпространства
+func (c SinusoidConfig) validate(n, order int) (int, error) {
+	if c.SampleRate <= 0 {
+		return 0, fmt.Errorf("sample rate must be positive, got %f", c.SampleRate)
+	}
+	if order < 1 {
+		return 0, fmt.Errorf("model order must be positive, got %d", c.Order)
+	}
+	switch c.Method {
+	case RootMUSIC, ESPRIT, MatrixPencil, Prony, Pisarenko:
+	default:
+		return 0, fmt.Errorf("unknown method %d", c.Method)
+	}
+	if c.Method == Prony {
+		if n < 2*order {
+			return 0, fmt.Errorf("Prony method needs at least %d samples, got %d", 2*order, n)
+		}
+		return 0, nil
+	}
+	if c.Method == Pisarenko {
+		// Единственный шумовой собственный вектор
+		if n < 2*order+1 {
+			return 0, fmt.Errorf("Pisarenko method needs at least %d samples, got %d", 2*order+1, n)
+		}
+		return order + 1, nil
+	}
+
+	m := c.Dimension
+	if m == 0 {
+		m = n / 3
+	}
+	if m <= order || n-m+1 <= order {
+		return 0, fmt.Errorf("dimension %d is incompatible with order %d and %d samples", m, order, n)
+	}
+	return m, nil
+}
+
+// EstimateSinusoids оценивает частоты, амплитуды, фазы и затухания order комплексных экспонент
+// Полюса z_k находятся выбранным методом, после чего комплексные амплитуды определяются
+// методом наименьших квадратов по матрице Вандермонда. Результат упорядочен по частоте.
+func EstimateSinusoids(x []complex128, config SinusoidConfig) ([]Sinusoid, error) {
+	m, err := config.validate(len(x), config.Order)
+	if err != nil {
+		return nil, err
+	}
+
+	var poles []complex128
+	switch config.Method {
+	case RootMUSIC, Pisarenko:
+		poles, err = rootMUSICPoles(x, config.Order, m)
+	case ESPRIT:
+		poles, err = espritPoles(x, config.Order, m)
+	case MatrixPencil:
+		poles, err = matrixPencilPoles(x, config.Order, m)
+	case Prony:
+		poles, err = pronyPoles(x, config.Order)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return fitSinusoids(x, poles, config.SampleRate)
+}
+
+// EstimateRealSinusoids оценивает параметры config.Order действительных тонов
+// A·cos(2πf·t + φ)·exp(-d·t). Каждый тон представляется парой сопряженных экспонент,
+// поэтому оценивается 2·Order экспонент, из которых выбираются Order компонент
+// с положительной частотой и наибольшей амплитудой.
+func EstimateRealSinusoids(x []float64, config SinusoidConfig) ([]Sinusoid, error) {
+	z := make([]complex128, len(x))
+	for i, v := range x {
+		z[i] = complex(v, 0)
+	}
+
+	complexConfig := config
+	complexConfig.Order = 2 * config.Order
+	components, err := EstimateSinusoids(z, complexConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var positive []Sinusoid
+	for _, c := range components {
+		if c.Frequency > 0 {
+			c.Amplitude *= 2
+			positive = append(positive, c)
+		}
+	}
+	sort.Slice(positive, func(i, j int) bool { return positive[i].Amplitude > positive[j].Amplitude })
+	if len(positive) > config.Order {
+		positive = positive[:config.Order]
+	}
+	sort.Slice(positive, func(i, j int) bool { return positive[i].Frequency < positive[j].Frequency })
+	return positive, nil
+}
+
+// rootMUSICPoles находит полюса корневым методом MUSIC
+// Многочлен D(z) = a^H(1/z*)·En·En^H·a(z) имеет пары корней z и 1/z*;
+// выбираются order корней внутри единичной окружности, ближайших к ней.
+func rootMUSICPoles(x []complex128, order, m int) ([]complex128, error) {
+	_, vectors := hermitianEigen(hankelCovariance(x, m))
+
+	// C = En·En^H по шумовым векторам
+	c := newComplexMatrix(m, m)
+	for k := order; k < m; k++ {
+		for i := 0; i < m; i++ {
+			for j := 0; j < m; j++ {
+				c[i][j] += vectors[i][k] * cmplx.Conj(vectors[j][k])
+			}
+		}
+	}
+
+	// Коэффициенты многочлена: сумма диагоналей C, от старшей степени к младшей
+	coeffs := make([]complex128, 2*m-1)
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			coeffs[i-j+m-1] += c[i][j]
+		}
+	}
+	roots, err := polynomialRoots(coeffs)
+	if err != nil {
+		return nil, err
+	}
+
+	var inside []complex128
+	for _, r := range roots {
+		if cmplx.Abs(r) <= 1 {
+			inside = append(inside, r)
+		}
+	}
+	if len(inside) < order {
+		return nil, fmt.Errorf("root-MUSIC found only %d roots inside the unit circle", len(inside))
+	}
+	sort.Slice(inside, func(i, j int) bool { return cmplx.Abs(inside[i]) > cmplx.Abs(inside[j]) })
+	return inside[:order], nil
+}
+
+// shiftInvariantPoles находит полюса по базису сигнального подпространства
+// (m строк, order столбцов): собственные значения Φ = pinv(U1)·U2,
+// где U1 и U2 - базис без последней и без первой строки
+func shiftInvariantPoles(basis [][]complex128) ([]complex128, error) {
+	m := len(basis)
+	phi, err := leastSquares(basis[:m-1], basis[1:])
+	if err != nil {
+		return nil, err
+	}
+	return eigenvalues(phi)
+}
+
+// espritPoles находит полюса методом ESPRIT по собственным векторам ковариационной матрицы
+func espritPoles(x []complex128, order, m int) ([]complex128, error) {
+	_, vectors := hermitianEigen(hankelCovariance(x, m))
+
+	basis := newComplexMatrix(m, order)
+	for i := 0; i < m; i++ {
+		copy(basis[i], vectors[i][:order])
+	}
+	return shiftInvariantPoles(basis)
+}
+
+// matrixPencilPoles находит полюса методом матричного пучка
+// Матрица данных Y ((N-L)×(L+1)) строится по строкам y_i = [x_i ... x_{i+L}];
+// ее старшие правые сингулярные векторы (собственные векторы Y^H·Y) образуют базис
+// пространства строк, в котором полюса присутствуют в сопряженном виде.
+func matrixPencilPoles(x []complex128, order, pencil int) ([]complex128, error) {
+	n := len(x)
+	cols := pencil + 1
+	rows := n - pencil
+	if rows < order {
+		return nil, fmt.Errorf("pencil parameter %d is too large for %d samples", pencil, n)
+	}
+
+	gram := newComplexMatrix(cols, cols)
+	for r := 0; r < rows; r++ {
+		for i := 0; i < cols; i++ {
+			ci := cmplx.Conj(x[r+i])
+			for j := 0; j < cols; j++ {
+				gram[i][j] += ci * x[r+j]
+			}
+		}
+	}
+	_, vectors := hermitianEigen(gram)
+
+	basis := newComplexMatrix(cols, order)
+	for i := 0; i < cols; i++ {
+		for k := 0; k < order; k++ {
+			basis[i][k] = cmplx.Conj(vectors[i][k])
+		}
+	}
+	return shiftInvariantPoles(basis)
+}
+
+// pronyPoles находит полюса методом Прони
+// Коэффициенты линейного предсказания x[n] = -Σ a_k·x[n-k] находятся методом
+// наименьших квадратов, полюса - корни многочлена z^p + a_1·z^(p-1) + ... + a_p.
+// При наличии шума оценки смещены; метод пригоден для сигналов с высоким SNR.
+func pronyPoles(x []complex128, order int) ([]complex128, error) {
+	rows := len(x) - order
+	a := newComplexMatrix(rows, order)
+	b := newComplexMatrix(rows, 1)
+	for r := 0; r < rows; r++ {
+		n := r + order
+		for k := 1; k <= order; k++ {
+			a[r][k-1] = x[n-k]
+		}
+		b[r][0] = -x[n]
+	}
+
+	coeffs, err := leastSquares(a, b)
+	if err != nil {
+		return nil, err
+	}
+	poly := make([]complex128, order+1)
+	poly[0] = 1
+	for k := 1; k <= order; k++ {
+		poly[k] = coeffs[k-1][0]
+	}
+	return polynomialRoots(poly)
+}
+
+// fitSinusoids определяет комплексные амплитуды по найденным полюсам
+func fitSinusoids(x []complex128, poles []complex128, sampleRate float64) ([]Sinusoid, error) {
+	n := len(x)
+	v := newComplexMatrix(n, len(poles))
+	b := newComplexMatrix(n, 1)
+	for k, z := range poles {
+		p := complex(1, 0)
+		for i := 0; i < n; i++ {
+			v[i][k] = p
+			p *= z
+		}
+	}
+	for i := range x {
+		b[i][0] = x[i]
+	}
+
+	amplitudes, err := leastSquares(v, b)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Sinusoid, len(poles))
+	for k, z := range poles {
+		c := amplitudes[k][0]
+		result[k] = Sinusoid{
+			Frequency: cmplx.Phase(z) * sampleRate / (2 * math.Pi),
+			Amplitude: cmplx.Abs(c),
+			Phase:     cmplx.Phase(c),
+			Damping:   -math.Log(cmplx.Abs(z)) * sampleRate,
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Frequency < result[j].Frequency })
+	return result, nil
+}
+
+// OrderCriterion - информационный критерий выбора порядка модели
+type OrderCriterion int
+
+const (
+	MDL OrderCriterion = iota // Минимальная длина описания (Rissanen), состоятельная оценка
+	AIC                       // Информационный критерий Акаике, склонен к завышению порядка
+)
+
+// String возвращает название критерия
+func (c OrderCriterion) String() string {
+	switch c {
+	case MDL:
+		return "MDL"
+	case AIC:
+		return "AIC"
+	default:
+		return "Unknown"
+	}
+}
+
+// EstimateModelOrder оценивает число комплексных экспонент в сигнале по собственным
+// значениям ковариационной матрицы размера dimension (0 - N/3) критерием Wax & Kailath:
+// L(k) = K·(m-k)·ln(арифм. среднее / геом. среднее младших m-k собственных значений),
+// AIC(k) = 2L(k) + 2k(2m-k), MDL(k) = L(k) + k(2m-k)·ln(K)/2, K = N-m+1.
+// Для действительного сигнала каждый тон дает две экспоненты.
+func EstimateModelOrder(x []complex128, dimension int, criterion OrderCriterion) (int, error) {
+	m := dimension
+	if m == 0 {
+		m = len(x) / 3
+	}
+	if m < 2 || m > len(x) {
+		return 0, fmt.Errorf("dimension must be in [2, %d], got %d", len(x), m)
+	}
+	if criterion != MDL && criterion != AIC {
+		return 0, fmt.Errorf("unknown criterion %d", criterion)
+	}
+
+	values, _ := hermitianEigen(hankelCovariance(x, m))
+	snapshots := float64(len(x) - m + 1)
+
+	best, bestScore := 0, math.Inf(1)
+	for k := 0; k < m; k++ {
+		var arith, logGeo float64
+		for _, v := range values[k:] {
+			v = math.Max(v, 1e-300)
+			arith += v
+			logGeo += math.Log(v)
+		}
+		count := float64(m - k)
+		arith /= count
+		logGeo /= count
+		likelihood := snapshots * count * (math.Log(arith) - logGeo)
+
+		kf, mf := float64(k), float64(m)
+		var score float64
+		if criterion == AIC {
+			score = 2*likelihood + 2*kf*(2*mf-kf)
+		} else {
+			score = likelihood + kf*(2*mf-kf)*math.Log(snapshots)/2
+		}
+		if score < bestScore {
+			best, bestScore = k, score
+		}
+	}
+	return best, nil
+}
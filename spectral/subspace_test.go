@@ -0,0 +1,119 @@
+package spectral
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+// exponentials формирует сумму комплексных экспонент с шумом
+func exponentials(components []Sinusoid, n int, fs, sigma float64, seed int64) []complex128 {
+	rng := rand.New(rand.NewSource(seed))
+	x := make([]complex128, n)
+	for i := range x {
+		ti := float64(i) / fs
+		for _, c := range components {
+			x[i] += complex(c.Amplitude*math.Exp(-c.Damping*ti), 0) *
+				cmplx.Exp(complex(0, 2*math.Pi*c.Frequency*ti+c.Phase))
+		}
+		x[i] += complex(sigma*rng.NormFloat64(), sigma*rng.NormFloat64())
+	}
+	return x
+}
+
+// TestEstimateSinusoids проверяет разрешение близких затухающих экспонент всеми методами
+func TestEstimateSinusoids(t *testing.T) {
+	fs := 1000.0
+	n := 128
+	want := []Sinusoid{
+		{Frequency: -210, Amplitude: 0.5, Phase: -1.0, Damping: 0},
+		{Frequency: 100, Amplitude: 1.0, Phase: 0.4, Damping: 5},
+		{Frequency: 106, Amplitude: 0.8, Phase: 2.0, Damping: 0},
+	}
+	for _, method := range []SinusoidMethod{RootMUSIC, ESPRIT, MatrixPencil, Prony, Pisarenko} {
+		// Методы Прони и Писаренко смещены при шуме, поэтому для них шум значительно слабее
+		sigma := 1e-3
+		if method == Prony || method == Pisarenko {
+			sigma = 1e-6
+		}
+		x := exponentials(want, n, fs, sigma, 1)
+
+		got, err := EstimateSinusoids(x, SinusoidConfig{SampleRate: fs, Order: len(want), Method: method})
+		if err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("%s: expected %d components, got %d", method, len(want), len(got))
+		}
+		for i := range want {
+			g, w := got[i], want[i]
+			if math.Abs(g.Frequency-w.Frequency) > 0.05 ||
+				math.Abs(g.Amplitude-w.Amplitude) > 0.01 ||
+				math.Abs(g.Phase-w.Phase) > 0.02 ||
+				math.Abs(g.Damping-w.Damping) > 0.5 {
+				t.Errorf("%s: component %d = %+v, want %+v", method, i, g, w)
+			}
+		}
+	}
+}
+
+// TestEstimateRealSinusoids проверяет оценку действительных тонов
+func TestEstimateRealSinusoids(t *testing.T) {
+	fs := 8000.0
+	n := 200
+	rng := rand.New(rand.NewSource(2))
+	x := make([]float64, n)
+	for i := range x {
+		ti := float64(i) / fs
+		x[i] = 1.0*math.Cos(2*math.Pi*1000*ti+0.3) + 0.5*math.Cos(2*math.Pi*1050*ti-1.2) + 0.01*rng.NormFloat64()
+	}
+
+	for _, method := range []SinusoidMethod{RootMUSIC, ESPRIT, MatrixPencil} {
+		got, err := EstimateRealSinusoids(x, SinusoidConfig{SampleRate: fs, Order: 2, Method: method})
+		if err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+		if len(got) != 2 {
+			t.Fatalf("%s: expected 2 tones, got %d", method, len(got))
+		}
+		if math.Abs(got[0].Frequency-1000) > 1 || math.Abs(got[1].Frequency-1050) > 1 {
+			t.Errorf("%s: frequencies %f, %f", method, got[0].Frequency, got[1].Frequency)
+		}
+		if math.Abs(got[0].Amplitude-1) > 0.05 || math.Abs(got[1].Amplitude-0.5) > 0.05 {
+			t.Errorf("%s: amplitudes %f, %f", method, got[0].Amplitude, got[1].Amplitude)
+		}
+	}
+}
+
+// TestEstimateModelOrder проверяет выбор порядка модели
+func TestEstimateModelOrder(t *testing.T) {
+	fs := 1000.0
+	components := []Sinusoid{
+		{Frequency: 50, Amplitude: 1},
+		{Frequency: 120, Amplitude: 0.7, Phase: 1},
+		{Frequency: 300, Amplitude: 0.5, Phase: 2},
+	}
+	x := exponentials(components, 256, fs, 0.1, 3)
+
+	order, err := EstimateModelOrder(x, 16, MDL)
+	if err != nil {
+		t.Fatalf("EstimateModelOrder failed: %v", err)
+	}
+	if order != 3 {
+		t.Errorf("MDL order %d, want 3", order)
+	}
+	if order, _ := EstimateModelOrder(x, 16, AIC); order < 3 {
+		t.Errorf("AIC order %d, want at least 3", order)
+	}
+
+	if _, err := EstimateModelOrder(x, 1, MDL); err == nil {
+		t.Error("expected error for dimension 1")
+	}
+	if _, err := EstimateSinusoids(x, SinusoidConfig{SampleRate: fs, Order: 0}); err == nil {
+		t.Error("expected error for zero order")
+	}
+	if _, err := EstimateSinusoids(x, SinusoidConfig{SampleRate: fs, Order: 3, Dimension: 3}); err == nil {
+		t.Error("expected error for dimension not exceeding order")
+	}
+}
@@ -0,0 +1,114 @@
+package spectral
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+
+	"github.com/Alexxtn105/dsp/fft"
+)
+
+// GCCWeighting - частотное взвешивание обобщенной взаимной корреляции
+type GCCWeighting int
+
+const (
+	GCCNone GCCWeighting = iota // Обычная взаимная корреляция
+	GCCPHAT                     // Фазовое преобразование: Ψ = 1/|Pxy|, острый пик при широкополосном сигнале
+	GCCSCOT                     // Сглаженное когерентное преобразование: Ψ = 1/√(Pxx·Pyy)
+)
+
+// String возвращает название взвешивания
+func (w GCCWeighting) String() string {
+	switch w {
+	case GCCNone:
+		return "CC"
+	case GCCPHAT:
+		return "PHAT"
+	case GCCSCOT:
+		return "SCOT"
+	default:
+		return "Unknown"
+	}
+}
+
+// DelayEstimate - результат оценки временной задержки
+type DelayEstimate struct {
+	Delay       float64   // Задержка канала y относительно x (с), положительная - y запаздывает
+	Samples     float64   // Задержка в отсчетах с субдискретной интерполяцией
+	Peak        float64   // Значение максимума обобщенной корреляции
+	Lags        []float64 // Задержки (с), соответствующие отсчетам корреляции
+	Correlation []float64 // Обобщенная взаимная корреляционная функция
+}
+
+// EstimateDelay оценивает задержку сигнала y относительно x обобщенной взаимной корреляцией (GCC)
+// Взаимный спектр усредняется методом Уэлча; поиск ведется в диапазоне ±maxDelay секунд
+// (0 - весь диапазон ±NFFT/2 отсчетов). Положение максимума уточняется параболической
+// интерполяцией по трем отсчетам. Длина сегмента должна превышать удвоенную задержку.
+func EstimateDelay(x, y []float64, config WelchConfig, weighting GCCWeighting, maxDelay float64) (*DelayEstimate, error) {
+	if maxDelay < 0 {
+		return nil, fmt.Errorf("max delay must be non-negative, got %f", maxDelay)
+	}
+	est, err := welchAverage(x, y, config)
+	if err != nil {
+		return nil, err
+	}
+
+	nfft := est.nfft
+	spectrum := make([]complex128, nfft)
+	for k := range spectrum {
+		var weight float64
+		switch weighting {
+		case GCCNone:
+			weight = 1
+		case GCCPHAT:
+			if m := cmplx.Abs(est.pxy[k]); m > 0 {
+				weight = 1 / m
+			}
+		case GCCSCOT:
+			if d := est.pxx[k] * est.pyy[k]; d > 0 {
+				weight = 1 / math.Sqrt(d)
+			}
+		default:
+			return nil, fmt.Errorf("unknown GCC weighting %d", weighting)
+		}
+		spectrum[k] = est.pxy[k] * complex(weight, 0)
+	}
+	r := fft.IFFT(spectrum)
+
+	// Отсчеты корреляции от -maxLag до maxLag
+	maxLag := (nfft - 1) / 2
+	if maxDelay > 0 {
+		maxLag = min(maxLag, int(math.Ceil(maxDelay*config.SampleRate)))
+	}
+	result := &DelayEstimate{
+		Lags:        make([]float64, 2*maxLag+1),
+		Correlation: make([]float64, 2*maxLag+1),
+	}
+	best := 0
+	for i := range result.Correlation {
+		lag := i - maxLag
+		result.Lags[i] = float64(lag) / config.SampleRate
+		result.Correlation[i] = real(r[(lag+nfft)%nfft])
+		if result.Correlation[i] > result.Correlation[best] {
+			best = i
+		}
+	}
+
+	offset := 0.0
+	if best > 0 && best < len(result.Correlation)-1 {
+		offset = parabolicOffset(result.Correlation[best-1], result.Correlation[best], result.Correlation[best+1])
+	}
+	result.Samples = float64(best-maxLag) + offset
+	result.Delay = result.Samples / config.SampleRate
+	result.Peak = result.Correlation[best]
+	return result, nil
+}
+
+// parabolicOffset возвращает смещение вершины параболы, проходящей через три точки, в [-0.5, 0.5]
+func parabolicOffset(left, center, right float64) float64 {
+	den := left - 2*center + right
+	if den == 0 {
+		return 0
+	}
+	return math.Max(-0.5, math.Min(0.5, 0.5*(left-right)/den))
+}
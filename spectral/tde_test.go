@@ -0,0 +1,123 @@
+package spectral
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/Alexxtn105/dsp/fft"
+	"github.com/Alexxtn105/dsp/filters"
+)
+
+// fractionalDelay задерживает сигнал на delay отсчетов (циклически, в частотной области)
+func fractionalDelay(x []float64, delay float64) []float64 {
+	n := len(x)
+	spectrum := fft.FFTReal(x)
+	for k := range spectrum {
+		f := float64(k)
+		if k > n/2 {
+			f -= float64(n)
+		}
+		if n%2 == 0 && k == n/2 {
+			spectrum[k] = 0
+			continue
+		}
+		spectrum[k] *= cmplx.Exp(complex(0, -2*math.Pi*f*delay/float64(n)))
+	}
+	out := fft.IFFT(spectrum)
+	result := make([]float64, n)
+	for i, v := range out {
+		result[i] = real(v)
+	}
+	return result
+}
+
+// TestEstimateDelay проверяет оценку дробной задержки всеми видами взвешивания
+func TestEstimateDelay(t *testing.T) {
+	fs := 8000.0
+	n := 16384
+	source := whiteNoise(n, 1, 4)
+
+	for _, delay := range []float64{7.3, -12.65, 0.4} {
+		y := fractionalDelay(source, delay)
+		noise := whiteNoise(n, 0.3, 5)
+		for i := range y {
+			y[i] += noise[i]
+		}
+
+		for _, weighting := range []GCCWeighting{GCCNone, GCCPHAT, GCCSCOT} {
+			est, err := EstimateDelay(source, y, DefaultWelchConfig(fs, 512), weighting, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(est.Samples-delay) > 0.15 {
+				t.Errorf("%s: delay %.3f samples, want %.3f", weighting, est.Samples, delay)
+			}
+			if math.Abs(est.Delay-delay/fs) > 0.15/fs {
+				t.Errorf("%s: delay %g s, want %g", weighting, est.Delay, delay/fs)
+			}
+			if len(est.Lags) != len(est.Correlation) || len(est.Lags) != 511 {
+				t.Errorf("%s: got %d lags, want 511", weighting, len(est.Lags))
+			}
+		}
+	}
+}
+
+// TestEstimateDelayColoredSource проверяет, что PHAT обостряет пик корреляции узкополосного источника
+func TestEstimateDelayColoredSource(t *testing.T) {
+	fs := 8000.0
+	n := 16384
+	lowpass := filters.NewSecondOrderLowPass(0.05, 0.707)
+	source := lowpass.Process(whiteNoise(n, 1, 6))
+	y := fractionalDelay(source, 20)
+	noise := whiteNoise(n, 0.01, 7)
+	for i := range y {
+		y[i] += noise[i]
+	}
+
+	width := func(est *DelayEstimate) int {
+		count := 0
+		for _, c := range est.Correlation {
+			if c > est.Peak/2 {
+				count++
+			}
+		}
+		return count
+	}
+
+	cc, err := EstimateDelay(source, y, DefaultWelchConfig(fs, 1024), GCCNone, 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	phat, err := EstimateDelay(source, y, DefaultWelchConfig(fs, 1024), GCCPHAT, 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(phat.Samples-20) > 0.2 {
+		t.Errorf("PHAT delay %.3f samples, want 20", phat.Samples)
+	}
+	if math.Abs(cc.Samples-20) > 1 {
+		t.Errorf("CC delay %.3f samples, want 20", cc.Samples)
+	}
+	if width(phat) >= width(cc) {
+		t.Errorf("PHAT peak width %d is not narrower than CC width %d", width(phat), width(cc))
+	}
+	// Ограничение диапазона поиска: ±0.01 с = ±80 отсчетов
+	if len(phat.Lags) != 161 {
+		t.Errorf("got %d lags, want 161", len(phat.Lags))
+	}
+}
+
+// TestEstimateDelayErrors проверяет проверку параметров
+func TestEstimateDelayErrors(t *testing.T) {
+	x := make([]float64, 256)
+	if _, err := EstimateDelay(x, x, DefaultWelchConfig(1000, 64), GCCWeighting(7), 0); err == nil {
+		t.Error("expected error for unknown weighting")
+	}
+	if _, err := EstimateDelay(x, x, DefaultWelchConfig(1000, 64), GCCPHAT, -1); err == nil {
+		t.Error("expected error for negative max delay")
+	}
+	if _, err := EstimateDelay(x, x[:100], DefaultWelchConfig(1000, 64), GCCPHAT, 0); err == nil {
+		t.Error("expected error for length mismatch")
+	}
+}
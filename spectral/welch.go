@@ -0,0 +1,150 @@
+package spectral
+
+import (
+	"fmt"
+	"math/cmplx"
+
+	"github.com/Alexxtn105/dsp/fft"
+	"github.com/Alexxtn105/dsp/windows"
+)
+
+// WelchConfig - параметры усреднения периодограмм по методу Уэлча
+type WelchConfig struct {
+	SampleRate    float64                   // Частота дискретизации (Гц)
+	SegmentLength int                       // Длина сегмента (отсчетов)
+	Overlap       int                       // Перекрытие сегментов (отсчетов), должно быть меньше SegmentLength
+	NFFT          int                       // Размер БПФ (0 - равен SegmentLength)
+	Window        func([]float64) []float64 // Функция применения окна из пакета windows (nil - окно Хэннинга)
+}
+
+// DefaultWelchConfig возвращает конфигурацию с окном Хэннинга и перекрытием 50%
+func DefaultWelchConfig(sampleRate float64, segmentLength int) WelchConfig {
+	return WelchConfig{
+		SampleRate:    sampleRate,
+		SegmentLength: segmentLength,
+		Overlap:       segmentLength / 2,
+		Window:        windows.ApplyHannWindow,
+	}
+}
+
+// validate проверяет параметры и возвращает размер БПФ
+func (c WelchConfig) validate(length int) (int, error) {
+	if c.SampleRate <= 0 {
+		return 0, fmt.Errorf("sample rate must be positive, got %f", c.SampleRate)
+	}
+	if c.SegmentLength < 2 {
+		return 0, fmt.Errorf("segment length must be at least 2, got %d", c.SegmentLength)
+	}
+	if c.SegmentLength > length {
+		return 0, fmt.Errorf("segment length %d exceeds signal length %d", c.SegmentLength, length)
+	}
+	if c.Overlap < 0 || c.Overlap >= c.SegmentLength {
+		return 0, fmt.Errorf("overlap must be in [0, %d), got %d", c.SegmentLength, c.Overlap)
+	}
+	nfft := c.NFFT
+	if nfft == 0 {
+		nfft = c.SegmentLength
+	}
+	if nfft < c.SegmentLength {
+		return 0, fmt.Errorf("FFT size %d is smaller than segment length %d", nfft, c.SegmentLength)
+	}
+	return nfft, nil
+}
+
+// welchEstimate - усредненные двусторонние спектры двух каналов на полной сетке БПФ
+// Нормированы как плотности (ед.²/Гц): P = |FFT(w·x)|² / (fs·Σw²).
+type welchEstimate struct {
+	pxx, pyy []float64
+	pxy      []complex128 // conj(X)·Y
+	nfft     int
+	segments int
+}
+
+// welchAverage вычисляет усредненные авто- и взаимный спектры сигналов x и y
+func welchAverage(x, y []float64, config WelchConfig) (*welchEstimate, error) {
+	if len(x) != len(y) {
+		return nil, fmt.Errorf("signals must have equal length, got %d and %d", len(x), len(y))
+	}
+	nfft, err := config.validate(len(x))
+	if err != nil {
+		return nil, err
+	}
+
+	ones := make([]float64, config.SegmentLength)
+	for i := range ones {
+		ones[i] = 1
+	}
+	apply := config.Window
+	if apply == nil {
+		apply = windows.ApplyHannWindow
+	}
+	window := apply(ones)
+	var energy float64
+	for _, w := range window {
+		energy += w * w
+	}
+	if energy == 0 {
+		return nil, fmt.Errorf("window has zero energy")
+	}
+
+	est := &welchEstimate{
+		pxx:  make([]float64, nfft),
+		pyy:  make([]float64, nfft),
+		pxy:  make([]complex128, nfft),
+		nfft: nfft,
+	}
+	step := config.SegmentLength - config.Overlap
+	bx := make([]complex128, nfft)
+	by := make([]complex128, nfft)
+	for start := 0; start+config.SegmentLength <= len(x); start += step {
+		for i := range bx {
+			bx[i], by[i] = 0, 0
+		}
+		for i, w := range window {
+			bx[i] = complex(w*x[start+i], 0)
+			by[i] = complex(w*y[start+i], 0)
+		}
+		fx := fft.FFT(bx)
+		fy := fft.FFT(by)
+		for k := 0; k < nfft; k++ {
+			est.pxx[k] += real(fx[k])*real(fx[k]) + imag(fx[k])*imag(fx[k])
+			est.pyy[k] += real(fy[k])*real(fy[k]) + imag(fy[k])*imag(fy[k])
+			est.pxy[k] += cmplx.Conj(fx[k]) * fy[k]
+		}
+		est.segments++
+	}
+
+	scale := 1 / (float64(est.segments) * config.SampleRate * energy)
+	for k := 0; k < nfft; k++ {
+		est.pxx[k] *= scale
+		est.pyy[k] *= scale
+		est.pxy[k] *= complex(scale, 0)
+	}
+	return est, nil
+}
+
+// oneSidedScale возвращает множитель односторонней плотности для отсчета k
+func oneSidedScale(k, nfft int) float64 {
+	if k == 0 || (nfft%2 == 0 && k == nfft/2) {
+		return 1
+	}
+	return 2
+}
+
+// Welch вычисляет одностороннюю оценку спектральной плотности мощности методом Уэлча
+func Welch(x []float64, config WelchConfig) (*PowerSpectrum, error) {
+	est, err := welchAverage(x, x, config)
+	if err != nil {
+		return nil, err
+	}
+	bins := est.nfft/2 + 1
+	ps := &PowerSpectrum{
+		Frequencies: make([]float64, bins),
+		Density:     make([]float64, bins),
+	}
+	for k := 0; k < bins; k++ {
+		ps.Frequencies[k] = float64(k) * config.SampleRate / float64(est.nfft)
+		ps.Density[k] = oneSidedScale(k, est.nfft) * est.pxx[k]
+	}
+	return ps, nil
+}